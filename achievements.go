@@ -0,0 +1,267 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// achievementEvent is what just happened; only rules whose metric depends on
+// it are evaluated
+type achievementEvent int
+
+const (
+	eventCompletion achievementEvent = iota // Daily or todo completed, days backfilled
+	eventReminder                           // Reminder acknowledged
+	eventReference                          // Reference entry added or removed
+	eventAny                                // Startup or changes from the CLI
+)
+
+// achievementMetric is a number achievement rules compare against a threshold
+type achievementMetric struct {
+	event achievementEvent
+	value func(data *AppData) int
+}
+
+// Metrics rules can use, by the name given in AchievementRule.Metric
+var achievementMetrics = map[string]achievementMetric{
+	"streak": {eventCompletion, func(data *AppData) int {
+		best := 0
+		for _, daily := range data.Dailies {
+			best = max(best, daily.BestStreak)
+		}
+		return best
+	}},
+	"completions": {eventCompletion, func(data *AppData) int {
+		count := 0
+		for _, daily := range data.Dailies {
+			for _, record := range daily.History {
+				if record.Status == dayDone {
+					count++
+				}
+			}
+		}
+		return count
+	}},
+	"todos":         {eventCompletion, func(data *AppData) int { return len(data.TodoLog) }},
+	"perfect_weeks": {eventCompletion, perfectWeeks},
+	"level": {eventCompletion, func(data *AppData) int {
+		level, _, _ := levelProgress(totalXP(data.XPLedger, ""))
+		return level
+	}},
+	"routine_streak": {eventCompletion, func(data *AppData) int {
+		best := 0
+		for _, r := range data.Routines {
+			best = max(best, r.BestStreak)
+		}
+		return best
+	}},
+	"reference":    {eventReference, referenceAdded},
+	"acknowledged": {eventReminder, func(data *AppData) int { return data.Acknowledged }},
+}
+
+// Built-in achievements; settings.achievements adds to these
+var defaultAchievements = []AchievementRule{
+	{ID: "first_daily", Icon: "✅", Name: "First Step", Description: "Complete a daily", Metric: "completions", Threshold: 1},
+	{ID: "streak_7", Icon: "🔥", Name: "On a Roll", Description: "Reach a 7-day streak", Metric: "streak", Threshold: 7},
+	{ID: "streak_30", Icon: "🌋", Name: "Unstoppable", Description: "Reach a 30-day streak", Metric: "streak", Threshold: 30},
+	{ID: "streak_100", Icon: "💎", Name: "Centurion", Description: "Reach a 100-day streak", Metric: "streak", Threshold: 100},
+	{ID: "perfect_week", Icon: "🌟", Name: "Perfect Week", Description: "Finish every due daily for a whole week", Metric: "perfect_weeks", Threshold: 1},
+	{ID: "todos_10", Icon: "📝", Name: "Getting Things Done", Description: "Complete 10 rolling todos", Metric: "todos", Threshold: 10},
+	{ID: "todos_100", Icon: "🏅", Name: "Todo Crusher", Description: "Complete 100 rolling todos", Metric: "todos", Threshold: 100},
+	{ID: "reference_30", Icon: "📚", Name: "Librarian", Description: "Add 30 reference entries", Metric: "reference", Threshold: 30},
+	{ID: "acknowledged_50", Icon: "⏰", Name: "Alarm Tamer", Description: "Acknowledge 50 reminders", Metric: "acknowledged", Threshold: 50},
+	{ID: "routine_7", Icon: "🧭", Name: "Creature of Habit", Description: "Complete a routine 7 days running", Metric: "routine_streak", Threshold: 7},
+	{ID: "level_5", Icon: "⭐", Name: "Rising Star", Description: "Reach level 5", Metric: "level", Threshold: 5},
+	{ID: "level_10", Icon: "🌠", Name: "Veteran", Description: "Reach level 10", Metric: "level", Threshold: 10},
+}
+
+// achievementRules returns the built-in rules followed by the user's own.
+// Custom rules with an unknown metric are dropped; a custom rule reusing a
+// built-in ID replaces it.
+func achievementRules(settings Settings) []AchievementRule {
+	rules := append([]AchievementRule{}, defaultAchievements...)
+	for _, custom := range settings.Achievements {
+		if _, ok := achievementMetrics[custom.Metric]; !ok || custom.Threshold <= 0 {
+			continue
+		}
+		if custom.ID == "" {
+			custom.ID = strings.ToLower(strings.Join(strings.Fields(custom.Name), "_"))
+		}
+		if custom.Icon == "" {
+			custom.Icon = "🏆"
+		}
+		replaced := false
+		for i := range rules {
+			if rules[i].ID == custom.ID {
+				rules[i], replaced = custom, true
+			}
+		}
+		if !replaced {
+			rules = append(rules, custom)
+		}
+	}
+	return rules
+}
+
+// perfectWeeks counts finished Monday-Sunday weeks where every due daily was
+// done or excused on every day, with at least one daily due
+func perfectWeeks(data *AppData) int {
+	dailies := unarchived(data.Dailies)
+	today := logicalDay(time.Now())
+	first := today
+	for _, daily := range dailies {
+		if daily.Kind != kindAvoid {
+			first = min(first, earliestDay(daily, today))
+		}
+	}
+
+	count := 0
+	for week := weekStart(first); week < weekStart(today); week = addDays(week, 7) {
+		perfect, scored := true, false
+		for day := week; day < addDays(week, 7) && perfect; day = addDays(day, 1) {
+			if cell := combinedCell(dailies, day, today); cell.state == cellScored {
+				scored = true
+				perfect = cell.ratio >= 1
+			}
+		}
+		if perfect && scored {
+			count++
+		}
+	}
+	return count
+}
+
+// referenceAdded counts reference entries the user added, leaving out the
+// ones lif starts with
+func referenceAdded(data *AppData) int {
+	seeded := map[string]bool{}
+	for _, item := range initializeReference() {
+		seeded[item.Lang+"\x00"+item.Command] = true
+	}
+	count := 0
+	for _, item := range data.Reference {
+		if !seeded[item.Lang+"\x00"+item.Command] {
+			count++
+		}
+	}
+	return count
+}
+
+// unlockedAt returns when an achievement was unlocked
+func unlockedAt(data AppData, id string) (time.Time, bool) {
+	for _, badge := range data.Achievements {
+		if badge.ID == id {
+			return badge.Unlocked, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// unlockAchievements evaluates the rules affected by event and records the
+// ones newly reached. Unlocked badges are never taken away. The metric values
+// it computes are stored in values, which the views read instead of walking
+// the history on every render.
+func unlockAchievements(data *AppData, event achievementEvent, values map[string]int) []AchievementRule {
+	var unlocked []AchievementRule
+	fresh := map[string]bool{}
+	for _, rule := range achievementRules(data.Settings) {
+		metric := achievementMetrics[rule.Metric]
+		if _, done := unlockedAt(*data, rule.ID); done || (event != eventAny && metric.event != event) {
+			continue
+		}
+		value := values[rule.Metric]
+		if !fresh[rule.Metric] {
+			value = metric.value(data)
+			values[rule.Metric], fresh[rule.Metric] = value, true
+		}
+		if value >= rule.Threshold {
+			data.Achievements = append(data.Achievements, Badge{ID: rule.ID, Name: rule.Name, Icon: rule.Icon, Unlocked: time.Now()})
+			unlocked = append(unlocked, rule)
+		}
+	}
+	return unlocked
+}
+
+// achievementEvent unlocks what event earned and celebrates each badge
+func (m *model) achievementEvent(event achievementEvent) {
+	unlocked := unlockAchievements(&m.data, event, m.metricValues)
+	for _, rule := range unlocked {
+		m.notify(Notification{
+			Title:      "🏆 Achievement unlocked",
+			Message:    fmt.Sprintf("%s %s: %s", rule.Icon, rule.Name, rule.Description),
+			Level:      levelNormal,
+			SourceType: "achievement",
+		}, false)
+		m.pushAnimatedToast(fmt.Sprintf("Achievement unlocked: %s %s", rule.Icon, rule.Name))
+	}
+	if len(unlocked) > 0 {
+		saveData(m.data)
+	}
+}
+
+// nextAchievement is the locked rule closest to being reached, going by the
+// metric values from the last achievement event
+func nextAchievement(data *AppData, values map[string]int) (AchievementRule, int, bool) {
+	var next AchievementRule
+	var nextValue int
+	best := -1.0
+	for _, rule := range achievementRules(data.Settings) {
+		if _, done := unlockedAt(*data, rule.ID); done {
+			continue
+		}
+		value := values[rule.Metric]
+		if ratio := float64(value) / float64(rule.Threshold); ratio > best {
+			next, nextValue, best = rule, value, ratio
+		}
+	}
+	return next, nextValue, best >= 0
+}
+
+// trophySummary is the Home section: latest badges and what's next
+func (m model) trophySummary() string {
+	rules := achievementRules(m.data.Settings)
+	content := "\n" + statusDoneStyle.Render(fmt.Sprintf("🏆 Trophies (%d/%d, T for all)", len(m.data.Achievements), len(rules))) + "\n"
+
+	badges := append([]Badge{}, m.data.Achievements...)
+	sort.SliceStable(badges, func(i, j int) bool { return badges[i].Unlocked.After(badges[j].Unlocked) })
+	for _, badge := range badges[:min(len(badges), 3)] {
+		content += fmt.Sprintf("  %s %-22s %s\n", badge.Icon, badge.Name, badge.Unlocked.Format("Jan 2, 2006"))
+	}
+	if rule, value, ok := nextAchievement(&m.data, m.metricValues); ok {
+		content += fmt.Sprintf("  Next: %s %s, %d/%d\n", rule.Icon, rule.Name, value, rule.Threshold)
+	}
+	return content
+}
+
+func (m model) handleTrophyKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "T", "esc", "q":
+		m.showTrophies = false
+	}
+	return m, nil
+}
+
+// trophyView lists every achievement, unlocked ones with their date and the
+// rest with progress
+func (m model) trophyView() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("105"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	rules := achievementRules(m.data.Settings)
+	lines := []string{titleStyle.Render(fmt.Sprintf("🏆 Trophies  %d/%d unlocked", len(m.data.Achievements), len(rules))), ""}
+	for _, rule := range rules {
+		if at, done := unlockedAt(m.data, rule.ID); done {
+			lines = append(lines, fmt.Sprintf(" %s %-22s %s  %s", rule.Icon, rule.Name, rule.Description, statusDoneStyle.Render(at.Format("Jan 2, 2006"))))
+			continue
+		}
+		value := m.metricValues[rule.Metric]
+		progress := fmt.Sprintf("%s %d/%d", xpBar(min(value, rule.Threshold), rule.Threshold, 10), min(value, rule.Threshold), rule.Threshold)
+		lines = append(lines, dimStyle.Render(fmt.Sprintf(" 🔒 %-22s %s  %s", rule.Name, rule.Description, progress)))
+	}
+	return lipgloss.NewStyle().Padding(0, 1).Render(strings.Join(lines, "\n"))
+}
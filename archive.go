@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// inactive reports whether a daily is paused or archived: it is left out of
+// today's counts, resets and nudges, and its days are recorded as paused
+func inactive(daily Daily) bool {
+	return daily.Paused || daily.Archived
+}
+
+// partitionDailies moves the dailies the table is showing (archived ones
+// when showArchived, the rest otherwise) to the front, keeping their order,
+// so table rows still index m.data.Dailies directly. Returns how many.
+func partitionDailies(items []Daily, showArchived bool) int {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Archived == showArchived && items[j].Archived != showArchived
+	})
+	shown := 0
+	for _, daily := range items {
+		if daily.Archived == showArchived {
+			shown++
+		}
+	}
+	return shown
+}
+
+// activeDailies returns the dailies that are neither paused nor archived
+func activeDailies(dailies []Daily) []Daily {
+	var active []Daily
+	for _, daily := range dailies {
+		if !inactive(daily) {
+			active = append(active, daily)
+		}
+	}
+	return active
+}
+
+// unarchived drops archived dailies; paused ones stay, shown as excused
+func unarchived(dailies []Daily) []Daily {
+	var kept []Daily
+	for _, daily := range dailies {
+		if !daily.Archived {
+			kept = append(kept, daily)
+		}
+	}
+	return kept
+}
+
+// setInactive pauses or resumes a daily's day-to-day tracking. Today is
+// recorded as paused straight away unless it already has a result.
+func setInactive(daily *Daily, paused bool) {
+	today := logicalDay(time.Now())
+	if daily.History == nil {
+		daily.History = map[string]DayRecord{}
+	}
+	if paused && openOn(*daily, today) {
+		markDay(daily, today, dayPaused)
+	} else if !paused && daily.History[today].Status == dayPaused {
+		delete(daily.History, today)
+	}
+	daily.CurrentStreak = computeStreak(*daily, today)
+}
+
+// pauseSelected pauses or resumes the selected daily
+func (m *model) pauseSelected() {
+	cursor, ok := m.selectedSingleDaily()
+	if !ok || m.showArchived {
+		return
+	}
+
+	daily := &m.data.Dailies[cursor]
+	daily.Paused = !daily.Paused
+	setInactive(daily, daily.Paused)
+	if daily.Paused {
+		m.pushToast(fmt.Sprintf("⏸️ Paused: %s (streak held at %d)", daily.Task, daily.CurrentStreak), toastInfo)
+	} else {
+		m.pushToast(fmt.Sprintf("▶️ Resumed: %s", daily.Task), toastSuccess)
+	}
+	m.tables[0].SetRows(m.dailyRows())
+	saveData(m.data)
+}
+
+// archiveSelected archives the selected daily, or restores it when viewing
+// the archive
+func (m *model) archiveSelected() {
+	cursor, ok := m.selectedSingleDaily()
+	if !ok {
+		return
+	}
+
+	daily := &m.data.Dailies[cursor]
+	daily.Archived = !daily.Archived
+	setInactive(daily, inactive(*daily))
+	name := daily.Task
+	if daily.Archived {
+		m.pushToast(fmt.Sprintf("📦 Archived: %s (history kept, V to view)", name), toastInfo)
+	} else {
+		m.pushToast(fmt.Sprintf("📤 Restored: %s", name), toastSuccess)
+	}
+	m.tables[0].SetRows(m.dailyRows())
+	saveData(m.data)
+}
+
+// selectedDaily returns the index of the daily under the Dailies cursor. It
+// is false when the cursor isn't on a shown row, e.g. in an empty archive.
+func (m *model) selectedDaily() (int, bool) {
+	cursor := m.tables[0].Cursor()
+	return cursor, cursor >= 0 && cursor < m.dailyShown
+}
+
+// toggleArchiveView switches the Dailies table between active and archived
+func (m *model) toggleArchiveView() {
+	m.showArchived = !m.showArchived
+	m.tables[0].SetRows(m.dailyRows())
+	m.tables[0].SetCursor(0)
+	if m.showArchived {
+		m.pushToast("📦 Showing archived dailies (v restores, V goes back)", toastInfo)
+	} else {
+		m.pushToast("Showing active dailies", toastInfo)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Daily kinds stored in Daily.Kind; blank is a habit to build
+const kindAvoid = "avoid" // Succeeds every day unless marked as slipped
+
+// slipDays returns the days an avoid habit slipped, oldest first
+func slipDays(daily Daily) []string {
+	var days []string
+	for day, record := range daily.History {
+		if record.Status == daySlipped {
+			days = append(days, day)
+		}
+	}
+	sort.Strings(days)
+	return days
+}
+
+// avoidStart is the day tracking began, or today if it was never set
+func avoidStart(daily Daily, today string) string {
+	if daily.Since == "" || daily.Since > today {
+		return today
+	}
+	return daily.Since
+}
+
+// daysClean counts days since the last slip up to today, or since tracking
+// began. A slip today means zero; slips before tracking began don't count.
+func daysClean(daily Daily, today string) int {
+	start := avoidStart(daily, today)
+	last := start
+	for _, day := range slipDays(daily) {
+		if day >= start && day <= today {
+			last = day
+		}
+	}
+	return daysBetween(last, today) - excusedBetween(daily, last, addDays(today, 1))
+}
+
+// longestCleanRun is the longest stretch between slips, including the
+// current one
+func longestCleanRun(daily Daily, today string) int {
+	best, previous := 0, avoidStart(daily, today)
+	for _, day := range slipDays(daily) {
+		if day > today {
+			break
+		}
+		if day >= previous {
+			best = max(best, daysBetween(previous, day)-excusedBetween(daily, previous, day))
+			previous = day
+		}
+	}
+	return max(best, daysBetween(previous, today)-excusedBetween(daily, previous, addDays(today, 1)))
+}
+
+// setDailyKind switches a daily between building and avoiding. A new avoid
+// habit starts its clean run today.
+func setDailyKind(daily *Daily, avoid bool) {
+	switch {
+	case avoid && daily.Kind != kindAvoid:
+		today := logicalDay(time.Now())
+		daily.Kind = kindAvoid
+		daily.Since = today
+		daily.Status = "DONE"
+		if daily.History[today].Status == daySlipped {
+			daily.Status = "SLIPPED"
+		}
+		daily.BestStreak = 0
+	case !avoid && daily.Kind == kindAvoid:
+		daily.Kind = ""
+		daily.Since = ""
+		daily.Status = "INCOMPLETE"
+		daily.BestStreak = 0
+	}
+}
+
+// toggleSlip marks today as a slip for an avoid habit, or takes it back.
+// Returns true if today is now a slip.
+func toggleSlip(daily *Daily) bool {
+	today := logicalDay(time.Now())
+	if daily.History == nil {
+		daily.History = map[string]DayRecord{}
+	}
+	slipped := daily.History[today].Status == daySlipped
+	if slipped {
+		delete(daily.History, today)
+		daily.Status = "DONE"
+	} else {
+		daily.History[today] = DayRecord{Status: daySlipped}
+		daily.Status = "SLIPPED"
+	}
+	daily.CurrentStreak = daysClean(*daily, today)
+	daily.BestStreak = max(daily.ImportedBest, longestCleanRun(*daily, today))
+	return !slipped
+}
+
+// toggleSlipSelected records or undoes a slip on the selected avoid habit
+func (m *model) toggleSlipSelected(daily *Daily) {
+	clean := daily.CurrentStreak
+	if toggleSlip(daily) {
+		m.pushToast(fmt.Sprintf("✗ Slipped: %s (ended a %d day run, best %d)", daily.Task, clean, daily.BestStreak), toastWarning)
+	} else {
+		m.pushToast(fmt.Sprintf("↩️ Slip removed: %s, %d days clean", daily.Task, daily.CurrentStreak), toastSuccess)
+	}
+	m.tables[0].SetRows(m.dailyRows())
+	saveData(m.data)
+}
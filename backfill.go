@@ -0,0 +1,240 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const defaultBackfillDays = 7
+
+// backfillDay marks a daily done (or not done) on a past day and recomputes
+// its streaks. Days older than settings.backfill_days are refused.
+func backfillDay(data *AppData, daily *Daily, day string, done bool) error {
+	if _, err := time.Parse("2006-01-02", day); err != nil {
+		return fmt.Errorf("invalid date %q, want YYYY-MM-DD", day)
+	}
+	today := logicalDay(time.Now())
+	if day > today {
+		return fmt.Errorf("%s is in the future", day)
+	}
+	if limit := data.Settings.BackfillDays; daysBetween(day, today) > limit {
+		return fmt.Errorf("%s is more than %d days back (settings.backfill_days)", day, limit)
+	}
+
+	if daily.Kind == kindAvoid {
+		if start := avoidStart(*daily, today); day < start {
+			return fmt.Errorf("%s is before %s was tracked (since %s)", day, daily.Task, start)
+		}
+		// Done means clean: marking a day done removes its slip
+		if daily.History == nil {
+			daily.History = map[string]DayRecord{}
+		}
+		if done && daily.History[day].Status == daySlipped {
+			delete(daily.History, day)
+		} else if !done {
+			daily.History[day] = DayRecord{Status: daySlipped}
+		}
+		if day == today && done {
+			daily.Status = "DONE"
+		} else if day == today {
+			daily.Status = "SLIPPED"
+		}
+		recomputeStreaks(daily, today)
+		return nil
+	}
+
+	if day == today {
+		switch {
+		case daily.Target > 0 && done:
+			setAmount(data, daily, daily.Target)
+		case daily.Target > 0:
+			setAmount(data, daily, 0)
+		case done && daily.Status != "DONE":
+			completeDaily(data, daily)
+		case !done && daily.Status == "DONE":
+			undoTaskStreak(data, daily)
+			daily.LastCompleted = time.Time{}
+			daily.Status = "INCOMPLETE"
+		}
+		recomputeStreaks(daily, today)
+		return nil
+	}
+
+	if daily.History == nil {
+		daily.History = map[string]DayRecord{}
+	}
+	record := daily.History[day]
+	if done {
+		// A freeze spent on this day is no longer needed
+		if record.Status == dayFrozen && data.FreezeTokens < data.Settings.Freezes.Max {
+			data.FreezeTokens++
+		}
+		daily.History[day] = DayRecord{Status: dayDone, Amount: max(record.Amount, daily.Target)}
+		awardDailyXP(data, *daily, day)
+	} else if record.Status == dayDone {
+		markDay(daily, day, dayPartial)
+	}
+	recomputeStreaks(daily, today)
+	// Later days' streaks, and so their XP, may have changed
+	recomputeXP(data)
+	return nil
+}
+
+// recomputeStreaks rebuilds the current and best streak from the history.
+// Best never drops below the record carried over from before history existed.
+func recomputeStreaks(daily *Daily, today string) {
+	daily.CurrentStreak = computeStreak(*daily, today)
+	if daily.Kind == kindAvoid {
+		daily.BestStreak = max(daily.ImportedBest, longestCleanRun(*daily, today))
+		return
+	}
+	best := max(daily.ImportedBest, daily.CurrentStreak)
+	for day, record := range daily.History {
+		if record.Status == dayDone && day < today {
+			best = max(best, computeStreak(*daily, day))
+		}
+	}
+	daily.BestStreak = best
+}
+
+// dayRecordText describes what happened to a daily on day for the day picker
+func dayRecordText(daily Daily, day string) string {
+	record, recorded := daily.History[day]
+	switch {
+	case record.Status == daySlipped:
+		return "✗ slipped"
+	case daily.Kind == kindAvoid && day < avoidStart(daily, day):
+		return "· not tracked"
+	case daily.Kind == kindAvoid && !recorded:
+		return "✓ clean"
+	case record.Status == dayDone && daily.Target > 0:
+		return "✓ " + progressText(daily, record.Amount)
+	case record.Status == dayDone:
+		return "✓ done"
+	case record.Status == daySkipped:
+		return "⏭️ skipped"
+	case record.Status == dayFrozen:
+		return "❄️ frozen"
+	case record.Status == dayVacation:
+		return "🏖️ vacation"
+	case record.Status == dayTravel:
+		return "✈️ travel"
+	case record.Status == dayPaused:
+		return "⏸️ paused"
+	case recorded && daily.Target > 0:
+		return progressText(daily, record.Amount)
+	case !dueOn(daily, day):
+		return "rest day"
+	}
+	return "· not done"
+}
+
+// openBackfill shows the day picker for the selected daily
+func (m *model) openBackfill() {
+	cursor, ok := m.selectedSingleDaily()
+	if !ok {
+		return
+	}
+	m.showBackfill = true
+	m.backfillID = m.data.Dailies[cursor].ID
+	m.backfillCursor = 0
+}
+
+// backfillDaily returns the daily the day picker is editing
+func (m *model) backfillDaily() *Daily {
+	for i := range m.data.Dailies {
+		if m.data.Dailies[i].ID == m.backfillID {
+			return &m.data.Dailies[i]
+		}
+	}
+	return nil
+}
+
+func (m model) handleBackfillKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	daily := m.backfillDaily()
+	if daily == nil {
+		m.showBackfill = false
+		return m, nil
+	}
+	switch msg.String() {
+	case "B", "esc", "q":
+		m.showBackfill = false
+	case "up", "k":
+		if m.backfillCursor > 0 {
+			m.backfillCursor--
+		}
+	case "down", "j":
+		if m.backfillCursor < m.data.Settings.BackfillDays {
+			m.backfillCursor++
+		}
+	case " ", "enter":
+		day := addDays(logicalDay(time.Now()), -m.backfillCursor)
+		done := daily.History[day].Status != dayDone
+		if daily.Kind == kindAvoid {
+			done = daily.History[day].Status == daySlipped
+		}
+		if err := checkSpendable(m.data, backfillLoss(m.data, *daily, day, done)); err != nil {
+			m.pushToast("⚠️ "+err.Error(), toastWarning)
+			return m, nil
+		}
+		if err := backfillDay(&m.data, daily, day, done); err != nil {
+			m.pushToast("⚠️ "+err.Error(), toastWarning)
+			return m, nil
+		}
+		if daily.Kind == kindAvoid {
+			m.pushToast(fmt.Sprintf("%s on %s: %s, %d days clean", daily.Task, day, dayRecordText(*daily, day), daily.CurrentStreak), toastInfo)
+		} else if done {
+			m.pushToast(fmt.Sprintf("✅ %s marked done on %s, streak %d", daily.Task, day, daily.CurrentStreak), toastSuccess)
+		} else {
+			m.pushToast(fmt.Sprintf("%s unmarked on %s, streak %d", daily.Task, day, daily.CurrentStreak), toastWarning)
+		}
+		m.tables[0].SetRows(m.dailyRows())
+		saveData(m.data)
+		m.achievementEvent(eventCompletion)
+	}
+	return m, nil
+}
+
+func (m model) backfillView() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("105"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+
+	daily := m.backfillDaily()
+	if daily == nil {
+		return ""
+	}
+	lines := []string{
+		titleStyle.Render(fmt.Sprintf("📅 %s: past days", daily.Task)),
+		dimStyle.Render(fmt.Sprintf("   Streak %d, best %d", daily.CurrentStreak, daily.BestStreak)),
+		"",
+	}
+
+	// Keep the cursor inside the visible window
+	visible := max(m.getContentHeight()-3, 1)
+	start := 0
+	if m.backfillCursor >= visible {
+		start = m.backfillCursor - visible + 1
+	}
+	end := min(start+visible, m.data.Settings.BackfillDays+1)
+
+	today := logicalDay(time.Now())
+	for i := start; i < end; i++ {
+		day := addDays(today, -i)
+		label := dayTime(day).Format("Mon Jan 2")
+		if i == 0 {
+			label = "Today"
+		}
+		line := fmt.Sprintf("%-11s %s", label, dayRecordText(*daily, day))
+		if i == m.backfillCursor {
+			line = selectedStyle.Render(line)
+		}
+		lines = append(lines, " "+line)
+	}
+
+	return lipgloss.NewStyle().Padding(0, 1).Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,541 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func printUsage() {
+	fmt.Println(`Usage:
+  lif                     Start the TUI
+  lif ack [id|name]       Acknowledge ringing reminders (all if none given)
+  lif notify test [level] Send a test notification and report each backend
+  lif sound test [file]   Play a sound and report which player was chosen
+  lif done <id|name> [--date YYYY-MM-DD] [--undo]
+                          Mark a daily done (or not) today or on a past day
+  lif vacation [start end|off]
+                          Show, set (YYYY-MM-DD, inclusive) or clear vacation
+  lif routine [list]      List routines with today's progress
+  lif routine set <name> <daily[:min], ...> [--window HH:MM-HH:MM]
+                          Create or replace a routine, steps in order
+  lif routine delete <name>
+                          Delete a routine (its dailies are kept)
+  lif xp [--all]          Show level, XP and the latest ledger entries
+  lif achievements        List achievements, unlocked and locked
+  lif shop [list]         Show points, rewards and purchase history
+  lif shop add "<name> - <cost>"
+                          Add a reward (or change its cost)
+  lif shop buy <id|name>  Spend points on a reward
+  lif shop undo           Refund the last purchase
+  lif quests [--seed N] [--day YYYY-MM-DD]
+                          Show today's quests, or preview the quests a seed
+                          and day would generate from the current data
+  lif xp recompute        Recompute XP awards from current dailies
+  lif help                Show this message`)
+}
+
+// runCLI handles non-interactive subcommands and returns the exit code
+func runCLI(args []string) int {
+	switch args[0] {
+	case "ack", "acknowledge":
+		return cliAck(args[1:])
+	case "notify":
+		return cliNotify(args[1:])
+	case "sound":
+		return cliSound(args[1:])
+	case "done":
+		return cliDone(args[1:])
+	case "vacation":
+		return cliVacation(args[1:])
+	case "routine", "routines":
+		return cliRoutine(args[1:])
+	case "xp":
+		return cliXP(args[1:])
+	case "achievements", "trophies":
+		return cliAchievements()
+	case "shop":
+		return cliShop(args[1:])
+	case "quests":
+		return cliQuests(args[1:])
+	case "help", "-h", "--help":
+		printUsage()
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "lif: unknown command %q\n\n", args[0])
+		printUsage()
+		return 2
+	}
+}
+
+// matchesReminder reports whether a CLI selector (ID or name) refers to r
+func matchesReminder(r Reminder, selector string) bool {
+	if id, err := strconv.Atoi(selector); err == nil {
+		return r.ID == id
+	}
+	return normalizeText(r.Reminder) == normalizeText(selector)
+}
+
+// matchesDaily reports whether a CLI selector (ID or name) refers to d
+func matchesDaily(d Daily, selector string) bool {
+	if id, err := strconv.Atoi(selector); err == nil {
+		return d.ID == id
+	}
+	return strings.EqualFold(normalizeText(d.Task), normalizeText(selector))
+}
+
+func cliAck(args []string) int {
+	data := loadData()
+	selector := strings.Join(args, " ")
+
+	acked := 0
+	for i := range data.Reminders {
+		reminder := &data.Reminders[i]
+		if reminder.Status != "expired" || reminder.Acknowledged {
+			continue
+		}
+		if selector != "" && !matchesReminder(*reminder, selector) {
+			continue
+		}
+		acknowledgeReminder(&data, reminder)
+		fmt.Printf("Acknowledged: %s\n", reminder.Reminder)
+		acked++
+	}
+
+	if acked == 0 {
+		fmt.Fprintln(os.Stderr, "lif: no unacknowledged reminders matched")
+		return 1
+	}
+	saveData(data)
+	return 0
+}
+
+func cliNotify(args []string) int {
+	if len(args) == 0 || args[0] != "test" {
+		fmt.Fprintln(os.Stderr, "usage: lif notify test [normal|elevated|critical]")
+		return 2
+	}
+
+	level := levelNormal
+	if len(args) > 1 {
+		switch args[1] {
+		case "normal":
+		case "elevated":
+			level = levelElevated
+		case "critical":
+			level = levelCritical
+		default:
+			fmt.Fprintf(os.Stderr, "lif: unknown level %q\n", args[1])
+			return 2
+		}
+	}
+
+	data := loadData()
+	results := sendNotification(data.Settings, Notification{
+		Title:   "lif",
+		Message: "Test notification",
+		Level:   level,
+	})
+
+	failed := false
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Printf("%-8s FAILED: %v\n", result.Backend, result.Err)
+			failed = true
+		} else {
+			fmt.Printf("%-8s ok\n", result.Backend)
+		}
+	}
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+func cliSound(args []string) int {
+	if len(args) == 0 || args[0] != "test" {
+		fmt.Fprintln(os.Stderr, "usage: lif sound test [file]")
+		return 2
+	}
+
+	var file string
+	if len(args) > 1 {
+		file = args[1]
+		if _, err := os.Stat(file); err != nil {
+			fmt.Fprintf(os.Stderr, "lif: %v\n", err)
+			return 1
+		}
+	}
+
+	data := loadData()
+	cmd, player, path, err := startSound(data.Settings.Sound, file, 0, false)
+	if player.Name != "" {
+		fmt.Printf("player: %s (%s)\n", player.Name, player.Path)
+	}
+	if path != "" {
+		fmt.Printf("sound:  %s\n", path)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "lif: %v\n", err)
+		return 1
+	}
+	if err := cmd.Wait(); err != nil {
+		fmt.Fprintf(os.Stderr, "lif: %s exited: %v\n", player.Name, err)
+		return 1
+	}
+	return 0
+}
+
+func cliVacation(args []string) int {
+	data := loadData()
+	switch {
+	case len(args) == 0:
+		if data.Vacation.Start == "" {
+			fmt.Println("No vacation set")
+		} else {
+			fmt.Printf("Vacation: %s to %s\n", data.Vacation.Start, data.Vacation.End)
+		}
+		return 0
+	case len(args) == 1 && args[0] == "off":
+		data.Vacation = Vacation{}
+		saveData(data)
+		fmt.Println("Vacation cleared")
+		return 0
+	case len(args) == 2:
+		for _, day := range args {
+			if _, err := time.Parse("2006-01-02", day); err != nil {
+				fmt.Fprintf(os.Stderr, "lif: invalid date %q, want YYYY-MM-DD\n", day)
+				return 2
+			}
+		}
+		if args[1] < args[0] {
+			fmt.Fprintln(os.Stderr, "lif: vacation ends before it starts")
+			return 2
+		}
+		data.Vacation = Vacation{Start: args[0], End: args[1]}
+		saveData(data)
+		fmt.Printf("Vacation: %s to %s, dailies paused\n", args[0], args[1])
+		return 0
+	}
+	fmt.Fprintln(os.Stderr, "usage: lif vacation [YYYY-MM-DD YYYY-MM-DD | off]")
+	return 2
+}
+
+func cliDone(args []string) int {
+	day, undo := "", false
+	var words []string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--undo":
+			undo = true
+		case args[i] == "--date" && i+1 < len(args):
+			i++
+			day = args[i]
+		case strings.HasPrefix(args[i], "--date="):
+			day = strings.TrimPrefix(args[i], "--date=")
+		default:
+			words = append(words, args[i])
+		}
+	}
+	selector := strings.Join(words, " ")
+	if selector == "" {
+		fmt.Fprintln(os.Stderr, "usage: lif done <id|name> [--date YYYY-MM-DD] [--undo]")
+		return 2
+	}
+
+	data := loadData()
+	resetDailyTasks(&data)
+	if day == "" {
+		day = logicalDay(time.Now())
+	}
+	for i := range data.Dailies {
+		daily := &data.Dailies[i]
+		if !matchesDaily(*daily, selector) {
+			continue
+		}
+		if err := checkSpendable(data, backfillLoss(data, *daily, day, !undo)); err != nil {
+			fmt.Fprintf(os.Stderr, "lif: %v\n", err)
+			return 2
+		}
+		if err := backfillDay(&data, daily, day, !undo); err != nil {
+			fmt.Fprintf(os.Stderr, "lif: %v\n", err)
+			return 2
+		}
+		saveData(data)
+		verb := "done"
+		if undo {
+			verb = "not done"
+		}
+		fmt.Printf("%s marked %s on %s (streak %d, best %d)\n", daily.Task, verb, day, daily.CurrentStreak, daily.BestStreak)
+		return 0
+	}
+	fmt.Fprintf(os.Stderr, "lif: no daily matched %q\n", selector)
+	return 1
+}
+
+func cliRoutine(args []string) int {
+	data := loadData()
+	resetDailyTasks(&data)
+	if len(args) == 0 || args[0] == "list" {
+		if len(data.Routines) == 0 {
+			fmt.Println("No routines")
+			return 0
+		}
+		today := logicalDay(time.Now())
+		for _, r := range data.Routines {
+			done, total := routineProgress(&data, r, today)
+			fmt.Printf("%d  %s  %d/%d done, streak %d (best %d)", r.ID, r.Name, done, total, r.CurrentStreak, r.BestStreak)
+			if r.Window != "" {
+				fmt.Printf("  %s", r.Window)
+			}
+			fmt.Println()
+			for i, step := range r.Steps {
+				if daily, ok := routineDaily(&data, step); ok {
+					fmt.Printf("   %d. %s", i+1, daily.Task)
+					if step.Minutes > 0 {
+						fmt.Printf(" (%dm)", step.Minutes)
+					}
+					fmt.Println()
+				}
+			}
+		}
+		return 0
+	}
+
+	switch args[0] {
+	case "set":
+		window := ""
+		var words []string
+		for i := 1; i < len(args); i++ {
+			switch {
+			case args[i] == "--window" && i+1 < len(args):
+				i++
+				window = args[i]
+			case strings.HasPrefix(args[i], "--window="):
+				window = strings.TrimPrefix(args[i], "--window=")
+			default:
+				words = append(words, args[i])
+			}
+		}
+		if len(words) < 2 {
+			break
+		}
+		parsed, ok := parseWindow(window)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "lif: invalid window %q, want HH:MM-HH:MM\n", window)
+			return 2
+		}
+		steps, err := parseRoutineSteps(data, strings.Join(words[1:], " "))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "lif: %v\n", err)
+			return 1
+		}
+		setRoutine(&data, words[0], parsed, steps)
+		r, _ := routineOf(&data, steps[0].DailyID)
+		r.CurrentStreak = routineStreak(&data, *r, logicalDay(time.Now()))
+		r.BestStreak = max(r.BestStreak, r.CurrentStreak)
+		saveData(data)
+		fmt.Printf("Routine %s: %d steps\n", r.Name, len(r.Steps))
+		return 0
+	case "delete", "rm":
+		if len(args) < 2 {
+			break
+		}
+		name := strings.Join(args[1:], " ")
+		for i, r := range data.Routines {
+			if strings.EqualFold(r.Name, name) || strconv.Itoa(r.ID) == name {
+				data.Routines = append(data.Routines[:i], data.Routines[i+1:]...)
+				saveData(data)
+				fmt.Printf("Routine %s deleted\n", r.Name)
+				return 0
+			}
+		}
+		fmt.Fprintf(os.Stderr, "lif: no routine matched %q\n", name)
+		return 1
+	}
+	fmt.Fprintln(os.Stderr, "usage: lif routine [list | set <name> <daily[:min], ...> [--window HH:MM-HH:MM] | delete <name>]")
+	return 2
+}
+
+func cliXP(args []string) int {
+	data := loadData()
+	shown := 20
+	switch {
+	case len(args) == 0:
+	case args[0] == "--all":
+		shown = len(data.XPLedger)
+	case args[0] == "recompute":
+		before := totalXP(data.XPLedger, "")
+		if err := checkSpendable(data, recomputeLoss(data)); err != nil {
+			fmt.Fprintf(os.Stderr, "lif: %v\n", err)
+			return 1
+		}
+		recomputeXP(&data)
+		saveData(data)
+		fmt.Printf("XP recomputed: %d -> %d\n", before, totalXP(data.XPLedger, ""))
+		return 0
+	default:
+		fmt.Fprintln(os.Stderr, "usage: lif xp [--all | recompute]")
+		return 2
+	}
+
+	total := totalXP(data.XPLedger, "")
+	level, into, needed := levelProgress(total)
+	fmt.Printf("Level %d  %s %d/%d XP  (%d total, +%d today)\n", level, xpBar(into, needed, 20), into, needed, total, totalXP(data.XPLedger, logicalDay(time.Now())))
+	for _, entry := range data.XPLedger[max(len(data.XPLedger)-shown, 0):] {
+		detail := ""
+		if entry.Priority != "" {
+			detail = " (" + entry.Priority
+			if entry.Streak > 0 {
+				detail += fmt.Sprintf(", streak %d", entry.Streak)
+			}
+			detail += ")"
+		}
+		fmt.Printf("%s  %+4d  %-7s  %s%s\n", entry.Day, entry.Points, entry.Source, entry.Name, detail)
+	}
+	return 0
+}
+
+func cliAchievements() int {
+	data := loadData()
+	if unlocked := unlockAchievements(&data, eventAny, map[string]int{}); len(unlocked) > 0 {
+		saveData(data)
+		for _, rule := range unlocked {
+			sendNotification(data.Settings, Notification{
+				Title:      "🏆 Achievement unlocked",
+				Message:    fmt.Sprintf("%s %s: %s", rule.Icon, rule.Name, rule.Description),
+				Level:      levelNormal,
+				SourceType: "achievement",
+			})
+		}
+	}
+	for _, rule := range achievementRules(data.Settings) {
+		if at, done := unlockedAt(data, rule.ID); done {
+			fmt.Printf("%s %-22s %s (unlocked %s)\n", rule.Icon, rule.Name, rule.Description, at.Format("2006-01-02"))
+			continue
+		}
+		value := achievementMetrics[rule.Metric].value(&data)
+		fmt.Printf("🔒 %-22s %s (%d/%d)\n", rule.Name, rule.Description, min(value, rule.Threshold), rule.Threshold)
+	}
+	return 0
+}
+
+func cliShop(args []string) int {
+	data := loadData()
+	if len(args) == 0 || args[0] == "list" {
+		fmt.Printf("Points: %d\n", pointsBalance(data))
+		for _, reward := range data.Rewards {
+			fmt.Printf("%d  %-32s %5d pts\n", reward.ID, reward.Name, reward.Cost)
+		}
+		for _, tx := range data.Transactions {
+			line := fmt.Sprintf("%s  %+5d  %s", tx.Time.Format("2006-01-02 15:04"), tx.Amount, tx.Name)
+			if tx.Undone {
+				line += " (undone)"
+			}
+			fmt.Println(line)
+		}
+		return 0
+	}
+
+	value := strings.Join(args[1:], " ")
+	switch args[0] {
+	case "add":
+		reward, ok := parseReward(value)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "lif: couldn't read %q, want \"<name> - <cost>\"\n", value)
+			return 2
+		}
+		addReward(&data, reward)
+		saveData(data)
+		fmt.Printf("Reward %s: %d pts\n", reward.Name, reward.Cost)
+		return 0
+	case "buy":
+		for _, reward := range data.Rewards {
+			if strconv.Itoa(reward.ID) != value && !strings.EqualFold(reward.Name, value) {
+				continue
+			}
+			if err := buyReward(&data, reward); err != nil {
+				fmt.Fprintf(os.Stderr, "lif: %v\n", err)
+				return 1
+			}
+			saveData(data)
+			fmt.Printf("Bought %s for %d pts, %d left\n", reward.Name, reward.Cost, pointsBalance(data))
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "lif: no reward matched %q\n", value)
+		return 1
+	case "undo":
+		tx, err := undoPurchase(&data)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "lif: %v\n", err)
+			return 1
+		}
+		saveData(data)
+		fmt.Printf("Refunded %s, %d pts back\n", tx.Name, -tx.Amount)
+		return 0
+	}
+	fmt.Fprintln(os.Stderr, "usage: lif shop [list | add \"<name> - <cost>\" | buy <id|name> | undo]")
+	return 2
+}
+
+func cliQuests(args []string) int {
+	var day, seed string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--day" && i+1 < len(args):
+			i++
+			day = args[i]
+		case strings.HasPrefix(args[i], "--day="):
+			day = strings.TrimPrefix(args[i], "--day=")
+		case args[i] == "--seed" && i+1 < len(args):
+			i++
+			seed = args[i]
+		case strings.HasPrefix(args[i], "--seed="):
+			seed = strings.TrimPrefix(args[i], "--seed=")
+		default:
+			fmt.Fprintln(os.Stderr, "usage: lif quests [--seed N] [--day YYYY-MM-DD]")
+			return 2
+		}
+	}
+
+	data := loadData()
+	if resetDailyTasks(&data) {
+		saveData(data)
+	}
+	board := data.Quests
+	if day != "" || seed != "" {
+		// Preview only: nothing is saved
+		if day == "" {
+			day = logicalDay(time.Now())
+		}
+		if _, err := time.Parse("2006-01-02", day); err != nil {
+			fmt.Fprintf(os.Stderr, "lif: invalid date %q, want YYYY-MM-DD\n", day)
+			return 2
+		}
+		n := questSeed(day)
+		if seed != "" {
+			parsed, err := strconv.ParseInt(seed, 10, 64)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "lif: invalid seed %q\n", seed)
+				return 2
+			}
+			n = parsed
+		}
+		board = QuestBoard{Day: day, Seed: n, Quests: generateQuests(data, day, n)}
+	}
+
+	fmt.Printf("Quests for %s (seed %d)\n", board.Day, board.Seed)
+	if len(board.Quests) == 0 {
+		fmt.Println("  none, nothing to draw quests from")
+	}
+	for _, quest := range board.Quests {
+		mark := "[ ]"
+		if quest.Done {
+			mark = "[x]"
+		}
+		fmt.Printf("  %s %s (+%d XP, %s)\n", mark, quest.Title, quest.Bonus, quest.Kind)
+	}
+	return 0
+}
@@ -1,46 +1,867 @@
 package main
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Day boundary, applied from Settings by applyDaySettings whenever data is
+// loaded. Times before dayStartHour belong to the previous calendar day.
+var (
+	dayStartHour = defaultDayStartHour
+	dayLocation  *time.Location // Pinned timezone, nil = system local time
 )
 
-// get3AMDay returns the "day" string based on 3AM cutoff
-// Times before 3AM belong to the previous calendar day
-func get3AMDay(t time.Time) string {
-	// If time is before 3AM, it belongs to the previous day
-	if t.Hour() < 3 {
-		t = t.AddDate(0, 0, -1)
+const defaultDayStartHour = 3
+
+// applyDaySettings sets the day boundary from the user's settings
+func applyDaySettings(settings Settings) {
+	dayStartHour = defaultDayStartHour
+	if settings.DayStartHour >= 0 && settings.DayStartHour <= 23 {
+		dayStartHour = settings.DayStartHour
+	}
+	dayLocation = nil
+	if settings.Timezone != "" {
+		if loc, err := time.LoadLocation(settings.Timezone); err == nil {
+			dayLocation = loc
+		}
+	}
+}
+
+// inDayZone converts t to the pinned timezone, if any
+func inDayZone(t time.Time) time.Time {
+	if dayLocation != nil {
+		return t.In(dayLocation)
 	}
-	return t.Format("2006-01-02")
+	return t
 }
 
-func updateTaskStreak(daily *Daily) {
-	now := time.Now()
-	today := get3AMDay(now)
+// logicalDay returns the day key ("2006-01-02") that t belongs to
+func logicalDay(t time.Time) string {
+	return dayKey(inDayZone(t))
+}
 
-	// Check if already completed today using LastCompleted
-	if !daily.LastCompleted.IsZero() && get3AMDay(daily.LastCompleted) == today {
-		return // Already completed today, don't update streak
+// dayKey returns the day key for t in t's own location
+func dayKey(t time.Time) string {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if t.Hour() < dayStartHour {
+		day = day.AddDate(0, 0, -1)
 	}
+	return day.Format("2006-01-02")
+}
 
-	// Calculate yesterday based on 3AM cutoff
-	yesterdayTime := now.Add(-24 * time.Hour)
-	yesterday := get3AMDay(yesterdayTime)
+// dayStart returns when the logical day containing now began
+func dayStart(now time.Time) time.Time {
+	day := dayTime(logicalDay(now))
+	return time.Date(day.Year(), day.Month(), day.Day(), dayStartHour, 0, 0, 0, inDayZone(now).Location())
+}
 
-	// Update streak based on whether we completed yesterday
-	if daily.LastCompleted.IsZero() {
-		// First time completing this task
-		daily.CurrentStreak = 1
-	} else if get3AMDay(daily.LastCompleted) == yesterday {
-		// Completed yesterday - continue streak
-		daily.CurrentStreak++
+// nextDayStart returns when the logical day containing now ends. time.Date
+// normalises the hour, so a DST shift never moves the boundary.
+func nextDayStart(now time.Time) time.Time {
+	day := dayTime(logicalDay(now))
+	return time.Date(day.Year(), day.Month(), day.Day()+1, dayStartHour, 0, 0, 0, inDayZone(now).Location())
+}
+
+// Periods a habit can repeat over: daily by default, or weekly and monthly
+// schedules that are done once per Monday-Sunday week or calendar month
+const (
+	periodDay   = "day"
+	periodWeek  = "week"
+	periodMonth = "month"
+)
+
+// periodStart returns the first day of the period containing day
+func periodStart(day, period string) string {
+	switch period {
+	case periodWeek:
+		return weekStart(day)
+	case periodMonth:
+		return day[:len("2006-01-")] + "01"
+	}
+	return day
+}
+
+// nextPeriodStart returns the first day of the period after the one
+// containing day. Like nextDayStart, the boundary falls at dayStartHour.
+func nextPeriodStart(day, period string) string {
+	switch period {
+	case periodWeek:
+		return addDays(weekStart(day), 7)
+	case periodMonth:
+		return dayTime(periodStart(day, periodMonth)).AddDate(0, 1, 0).Format("2006-01-02")
+	}
+	return addDays(day, 1)
+}
+
+// periodEnd returns the last day of the period containing day
+func periodEnd(day, period string) string {
+	return addDays(nextPeriodStart(day, period), -1)
+}
+
+// doneInPeriod reports whether a daily was completed in the period holding today
+func doneInPeriod(daily Daily, today string) bool {
+	period := dailySchedule(daily).period()
+	return doneInRange(daily, periodStart(today, period), today) > 0
+}
+
+// lastChanceOn reports whether day is the final day to keep the streak for
+// the current period: every due day for dailies, the period's end otherwise
+func lastChanceOn(daily Daily, day string) bool {
+	period := dailySchedule(daily).period()
+	return period == periodDay || day == periodEnd(day, period)
+}
+
+// completedDay returns the day whose completion makes the daily DONE now:
+// today, or for weekly and monthly habits the latest done day this period
+func completedDay(daily Daily, today string) string {
+	period := dailySchedule(daily).period()
+	latest := today
+	for day := periodStart(today, period); day <= today; day = addDays(day, 1) {
+		if doneOn(daily, day) {
+			latest = day
+		}
+	}
+	return latest
+}
+
+// The system zone's name, resolved again only when the UTC offset or the
+// day changes rather than on every tick
+var localZone struct {
+	name   string
+	offset int
+	day    string
+}
+
+// zoneIdentity names the timezone days are counted in: the pinned zone, the
+// system zone's IANA name, or failing both its winter and summer offsets.
+// Unlike the UTC offset it stays the same across DST changes.
+func zoneIdentity(now time.Time) string {
+	if dayLocation != nil {
+		return dayLocation.String()
+	}
+	_, offset := now.In(time.Local).Zone()
+	day := logicalDay(now)
+	if localZone.name == "" || localZone.offset != offset || localZone.day != day {
+		localZone.name, localZone.offset, localZone.day = localZoneName(now), offset, day
+	}
+	return localZone.name
+}
+
+// localZoneName resolves the system zone's name from TZ or /etc/localtime
+func localZoneName(now time.Time) string {
+	if tz := strings.TrimPrefix(os.Getenv("TZ"), ":"); tz != "" {
+		return tz
+	}
+	if path, err := filepath.EvalSymlinks("/etc/localtime"); err == nil {
+		if _, name, ok := strings.Cut(path, "zoneinfo/"); ok && name != "" {
+			return name
+		}
+	}
+	_, winter := time.Date(now.Year(), time.January, 1, 12, 0, 0, 0, time.Local).Zone()
+	_, summer := time.Date(now.Year(), time.July, 1, 12, 0, 0, 0, time.Local).Zone()
+	return fmt.Sprintf("UTC%+d/%+d", winter, summer)
+}
+
+// excuseTravelDays marks days skipped over by a timezone change. Flying east
+// can move "today" past a day that never finished in the old zone; those days
+// are recorded as travel so they don't break streaks. DST changes the offset
+// without changing the zone and excuses nothing. Returns true if the zone
+// changed.
+func excuseTravelDays(data *AppData, now time.Time) bool {
+	_, offset := inDayZone(now).Zone()
+	zone := zoneIdentity(now)
+	if data.LastUTCOffset == nil || data.LastZone == "" || data.LastZone == zone || *data.LastUTCOffset == offset {
+		data.LastUTCOffset, data.LastZone = &offset, zone
+		return false
+	}
+
+	oldToday := dayKey(now.In(time.FixedZone("", *data.LastUTCOffset)))
+	today := logicalDay(now)
+	for day := oldToday; day < today; day = addDays(day, 1) {
+		for i := range data.Dailies {
+			daily := &data.Dailies[i]
+			if daily.History == nil {
+				daily.History = map[string]DayRecord{}
+			}
+			if openOn(*daily, day) {
+				markDay(daily, day, dayTravel)
+			}
+		}
+	}
+	data.LastUTCOffset, data.LastZone = &offset, zone
+	return true
+}
+
+// updateTaskStreak records today's completion and recomputes the streak
+// from the history. Every EarnEvery streak days earn a freeze token.
+func updateTaskStreak(data *AppData, daily *Daily) {
+	today := logicalDay(time.Now())
+	if daily.History == nil {
+		daily.History = map[string]DayRecord{}
+	}
+	amount := daily.History[today].Amount
+	daily.History[today] = DayRecord{Status: dayDone, Amount: amount}
+	refreshStreak(daily, today)
+	awardDailyXP(data, *daily, today)
+
+	freezes := data.Settings.Freezes
+	if freezes.EarnEvery > 0 && daily.CurrentStreak%freezes.EarnEvery == 0 && data.FreezeTokens < freezes.Max {
+		data.FreezeTokens++
+		daily.History[today] = DayRecord{Status: dayDone, EarnedFreeze: true, Amount: amount}
+	}
+}
+
+// undoTaskStreak removes today's completion, handing back any freeze it
+// earned. Best streak is recomputed so an undone completion can't raise it.
+func undoTaskStreak(data *AppData, daily *Daily) {
+	today := logicalDay(time.Now())
+	day := completedDay(*daily, today)
+	if daily.History[day].EarnedFreeze && data.FreezeTokens > 0 {
+		data.FreezeTokens--
+	}
+	delete(daily.History, day)
+	recomputeStreaks(daily, today)
+	revokeXP(data, linkDaily, daily.ID, day)
+	updateRoutineStreak(data, *daily)
+}
+
+// toggleSkip marks today as not applicable for a daily, or clears the skip
+func toggleSkip(daily *Daily) bool {
+	today := logicalDay(time.Now())
+	if daily.History == nil {
+		daily.History = map[string]DayRecord{}
+	}
+	skipped := daily.History[today].Status == daySkipped
+	if skipped {
+		markDay(daily, today, dayPartial)
 	} else {
-		// Streak broken - reset to 1
-		daily.CurrentStreak = 1
+		markDay(daily, today, daySkipped)
+	}
+	daily.CurrentStreak = computeStreak(*daily, today)
+	return !skipped
+}
+
+// onVacation reports whether day falls inside the vacation range
+func (v Vacation) onVacation(day string) bool {
+	return v.Start != "" && v.End != "" && day >= v.Start && day <= v.End
+}
+
+// openOn reports whether nothing settles day yet: no record, or only
+// partial progress
+func openOn(daily Daily, day string) bool {
+	record, recorded := daily.History[day]
+	return !recorded || record.Status == dayPartial
+}
+
+// markDay sets the status of day, keeping any logged amount. Marking a day
+// partial with nothing logged clears it.
+func markDay(daily *Daily, day, status string) {
+	if daily.History == nil {
+		daily.History = map[string]DayRecord{}
+	}
+	amount := daily.History[day].Amount
+	if status == dayPartial && amount == 0 {
+		delete(daily.History, day)
+		return
+	}
+	daily.History[day] = DayRecord{Status: status, Amount: amount}
+}
+
+// missedOn reports whether a daily was due on day and nothing covers it
+func missedOn(daily Daily, day string) bool {
+	return openOn(daily, day) && dueOn(daily, day)
+}
+
+// rollover processes every day since the last run: days inside the vacation
+// range are recorded as vacation, and missed days that would break a streak
+// consume a freeze token if one is available. Today only gets vacation
+// marking. Returns the number of freezes used.
+func rollover(data *AppData, today string) int {
+	if data.LastRollover == "" || data.LastRollover > today {
+		data.LastRollover = today
+	}
+	start := data.LastRollover
+	if daysBetween(start, today) > 366 {
+		start = addDays(today, -366)
+	}
+
+	used := 0
+	for day := start; day <= today; day = addDays(day, 1) {
+		for i := range data.Dailies {
+			daily := &data.Dailies[i]
+			if daily.History == nil {
+				daily.History = map[string]DayRecord{}
+			}
+			if inactive(*daily) {
+				if openOn(*daily, day) {
+					markDay(daily, day, dayPaused)
+				}
+				continue
+			}
+			if daily.Kind == kindAvoid {
+				// Nothing is missed on an avoid habit, only slipped
+				continue
+			}
+			if openOn(*daily, day) && data.Vacation.onVacation(day) {
+				markDay(daily, day, dayVacation)
+				continue
+			}
+			if day == today || data.FreezeTokens == 0 || computeStreak(*daily, day) == 0 {
+				continue
+			}
+			s := dailySchedule(*daily)
+			if period := s.period(); period != periodDay {
+				// Weekly and monthly habits are judged on the period's last day
+				start, end := periodStart(day, period), periodEnd(day, period)
+				if day == end && doneInRange(*daily, start, end) == 0 && excusedBetween(*daily, addDays(start, -1), addDays(end, 1)) == 0 {
+					markDay(daily, day, dayFrozen)
+					data.FreezeTokens--
+					used++
+				}
+				continue
+			}
+			if s.kind == schedulePerWeek {
+				// Weekly targets are judged on Sunday: freeze the shortfall
+				if dayTime(day).Weekday() != time.Sunday {
+					continue
+				}
+				week := weekStart(day)
+				short := s.perWeek - doneInRange(*daily, week, day) - excusedBetween(*daily, addDays(week, -1), addDays(day, 1))
+				for d := day; d >= week && short > 0 && data.FreezeTokens > 0; d = addDays(d, -1) {
+					if openOn(*daily, d) {
+						markDay(daily, d, dayFrozen)
+						data.FreezeTokens--
+						used++
+						short--
+					}
+				}
+				continue
+			}
+			if missedOn(*daily, day) {
+				markDay(daily, day, dayFrozen)
+				data.FreezeTokens--
+				used++
+			}
+		}
+	}
+	data.LastRollover = today
+	return used
+}
+
+// Schedule kinds for Daily.Schedule
+const (
+	scheduleEveryDay = "daily"
+	scheduleWeekdays = "weekdays" // Specific days of the week
+	scheduleInterval = "interval" // Every N days since the last completion
+	schedulePerWeek  = "per week" // X completions per Monday-Sunday week
+	scheduleWeekly   = "weekly"   // Once per Monday-Sunday week
+	scheduleMonthly  = "monthly"  // Once per calendar month
+)
+
+// schedule is a parsed Daily.Schedule rule
+type schedule struct {
+	kind     string
+	weekdays [7]bool // Indexed by time.Weekday
+	every    int     // scheduleInterval: days between completions
+	perWeek  int     // schedulePerWeek: completions wanted each week
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// parseSchedule reads "mon/wed/fri", "weekdays", "weekends", "every 2 days",
+// "3x/week" ("3 times per week"), "weekly" or "monthly". Blank means every day.
+func parseSchedule(value string) (schedule, bool) {
+	value = normalizeText(value)
+	switch value {
+	case "", "daily", "every day", "everyday":
+		return schedule{kind: scheduleEveryDay}, true
+	case "weekdays":
+		return schedule{kind: scheduleWeekdays, weekdays: [7]bool{false, true, true, true, true, true, false}}, true
+	case "weekends":
+		return schedule{kind: scheduleWeekdays, weekdays: [7]bool{true, false, false, false, false, false, true}}, true
+	case "weekly", "every week", "once a week":
+		return schedule{kind: scheduleWeekly}, true
+	case "monthly", "every month", "once a month":
+		return schedule{kind: scheduleMonthly}, true
+	}
+
+	var n int
+	for _, format := range []string{"every %dd", "every %d day"} {
+		if _, err := fmt.Sscanf(value, format, &n); err == nil && n > 0 {
+			if n == 1 {
+				return schedule{kind: scheduleEveryDay}, true
+			}
+			return schedule{kind: scheduleInterval, every: n}, true
+		}
+	}
+	for _, format := range []string{"%dx/week", "%dx per week", "%dx a week", "%d times per week", "%d times a week", "%d/week"} {
+		if _, err := fmt.Sscanf(value, format, &n); err == nil && n > 0 && n <= 7 {
+			return schedule{kind: schedulePerWeek, perWeek: n}, true
+		}
+	}
+
+	s := schedule{kind: scheduleWeekdays}
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '/' || r == ' ' }) {
+		if len(part) < 3 {
+			return schedule{}, false
+		}
+		day, ok := weekdayNames[part[:3]]
+		if !ok {
+			return schedule{}, false
+		}
+		s.weekdays[day] = true
+	}
+	return s, true
+}
+
+// label is the short form shown in the Dailies table
+func (s schedule) label() string {
+	switch s.kind {
+	case scheduleWeekdays:
+		var days []string
+		for i := 1; i <= 7; i++ {
+			// Monday first, Sunday last
+			if day := time.Weekday(i % 7); s.weekdays[day] {
+				days = append(days, strings.ToLower(day.String()[:3]))
+			}
+		}
+		return strings.Join(days, ",")
+	case scheduleInterval:
+		return fmt.Sprintf("every %dd", s.every)
+	case schedulePerWeek:
+		return fmt.Sprintf("%dx/week", s.perWeek)
+	case scheduleWeekly, scheduleMonthly:
+		return s.kind
+	}
+	return "daily"
+}
+
+// period is how long one completion lasts: a day, week or month
+func (s schedule) period() string {
+	switch s.kind {
+	case scheduleWeekly:
+		return periodWeek
+	case scheduleMonthly:
+		return periodMonth
+	}
+	return periodDay
+}
+
+// normalizeSchedule stores valid rules in their short form and leaves
+// anything else as typed (treated as every day)
+func normalizeSchedule(value string) string {
+	s, ok := parseSchedule(value)
+	if !ok {
+		return strings.TrimSpace(value)
+	}
+	if s.kind == scheduleEveryDay {
+		return ""
+	}
+	return s.label()
+}
+
+// dailySchedule parses a daily's schedule, treating invalid rules as every day
+func dailySchedule(daily Daily) schedule {
+	s, ok := parseSchedule(daily.Schedule)
+	if !ok {
+		return schedule{kind: scheduleEveryDay}
+	}
+	return s
+}
+
+// Day keys are "2006-01-02" strings. Arithmetic goes through calendar dates
+// so DST changes never shift a day.
+func dayTime(day string) time.Time {
+	t, _ := time.Parse("2006-01-02", day)
+	return t
+}
+
+func addDays(day string, n int) string {
+	return dayTime(day).AddDate(0, 0, n).Format("2006-01-02")
+}
+
+func daysBetween(from, to string) int {
+	return int(dayTime(to).Sub(dayTime(from)).Hours() / 24)
+}
+
+// weekStart returns the Monday of the week containing day
+func weekStart(day string) string {
+	offset := (int(dayTime(day).Weekday()) + 6) % 7
+	return addDays(day, -offset)
+}
+
+// doneOn reports whether a daily was completed on a day
+func doneOn(daily Daily, day string) bool {
+	return daily.History[day].Status == dayDone
+}
+
+// excusedOn reports whether a day neither counts toward nor breaks a streak
+func excusedOn(daily Daily, day string) bool {
+	switch daily.History[day].Status {
+	case daySkipped, dayFrozen, dayVacation, dayTravel, dayPaused:
+		return true
 	}
+	return false
+}
+
+// excusedBetween counts excused days strictly between from and to
+func excusedBetween(daily Daily, from, to string) int {
+	count := 0
+	for day := addDays(from, 1); day < to; day = addDays(day, 1) {
+		if excusedOn(daily, day) {
+			count++
+		}
+	}
+	return count
+}
 
-	// Update best streak if current is higher
+// lastDoneBefore returns the latest completion strictly before day
+func lastDoneBefore(daily Daily, day string) (string, bool) {
+	latest := ""
+	for d, record := range daily.History {
+		if record.Status == dayDone && d < day && d > latest {
+			latest = d
+		}
+	}
+	return latest, latest != ""
+}
+
+// doneInRange counts completions from start to end inclusive
+func doneInRange(daily Daily, start, end string) int {
+	count := 0
+	for d, record := range daily.History {
+		if record.Status == dayDone && d >= start && d <= end {
+			count++
+		}
+	}
+	return count
+}
+
+// earliestDay is the first day with any history, or day itself
+func earliestDay(daily Daily, day string) string {
+	earliest := day
+	for d := range daily.History {
+		if d < earliest {
+			earliest = d
+		}
+	}
+	return earliest
+}
+
+// dueOn reports whether a daily is expected on day under its schedule
+func dueOn(daily Daily, day string) bool {
+	s := dailySchedule(daily)
+	switch s.kind {
+	case scheduleWeekdays:
+		return s.weekdays[dayTime(day).Weekday()]
+	case scheduleInterval:
+		last, ok := lastDoneBefore(daily, day)
+		return !ok || daysBetween(last, day) >= s.every
+	case schedulePerWeek:
+		return doneInRange(daily, weekStart(day), addDays(day, -1)) < s.perWeek
+	case scheduleWeekly, scheduleMonthly:
+		return doneInRange(daily, periodStart(day, s.period()), addDays(day, -1)) == 0
+	}
+	return true
+}
+
+// computeStreak counts the current streak as of today. Today never breaks a
+// streak while it is still pending, and days the schedule doesn't require
+// are skipped rather than counted as misses.
+func computeStreak(daily Daily, today string) int {
+	if daily.Kind == kindAvoid {
+		return daysClean(daily, today)
+	}
+	s := dailySchedule(daily)
+	switch s.kind {
+	case scheduleInterval:
+		// Completions count while each gap, less excused days, stays within the interval
+		gap := func(from, to string) int { return daysBetween(from, to) - excusedBetween(daily, from, to) }
+		day, ok := today, doneOn(daily, today)
+		if !ok {
+			last, found := lastDoneBefore(daily, today)
+			if !found || gap(last, today) > s.every {
+				return 0
+			}
+			day = last
+		}
+		streak := 1
+		for {
+			prev, found := lastDoneBefore(daily, day)
+			if !found || gap(prev, day) > s.every {
+				return streak
+			}
+			streak++
+			day = prev
+		}
+	case scheduleWeekly, scheduleMonthly:
+		// Streaks count periods; the current one is in progress and excused
+		// periods neither count nor break
+		period := s.period()
+		start := periodStart(today, period)
+		streak := min(doneInRange(daily, start, today), 1)
+		first := periodStart(earliestDay(daily, today), period)
+		for start = periodStart(addDays(start, -1), period); start >= first; start = periodStart(addDays(start, -1), period) {
+			end := periodEnd(start, period)
+			switch {
+			case doneInRange(daily, start, end) > 0:
+				streak++
+			case excusedBetween(daily, addDays(start, -1), addDays(end, 1)) == 0:
+				return streak
+			}
+		}
+		return streak
+	case schedulePerWeek:
+		// The current week is in progress; earlier weeks must have met the target
+		week := weekStart(today)
+		streak := doneInRange(daily, week, today)
+		earliest := earliestDay(daily, today)
+		for week = addDays(week, -7); week >= weekStart(earliest); week = addDays(week, -7) {
+			// Each excused day lowers the week's target by one
+			done, excused := doneInRange(daily, week, addDays(week, 6)), excusedBetween(daily, addDays(week, -1), addDays(week, 7))
+			if done < s.perWeek-excused {
+				break
+			}
+			streak += done
+		}
+		return streak
+	}
+
+	streak := 0
+	earliest := earliestDay(daily, today)
+	for day := today; day >= earliest; day = addDays(day, -1) {
+		switch {
+		case doneOn(daily, day):
+			streak++
+		case day == today || !dueOn(daily, day) || excusedOn(daily, day):
+			// Pending today, not scheduled or excused: neither counts nor breaks
+		default:
+			return streak
+		}
+	}
+	return streak
+}
+
+// refreshStreak recomputes the current streak and raises the best streak
+func refreshStreak(daily *Daily, today string) {
+	daily.CurrentStreak = computeStreak(*daily, today)
 	if daily.CurrentStreak > daily.BestStreak {
 		daily.BestStreak = daily.CurrentStreak
 	}
 }
+
+// migrateHistory builds a completion history for dailies saved before
+// History existed, one done day per streak day ending at LastCompleted
+func migrateHistory(daily *Daily) {
+	if daily.History != nil {
+		return
+	}
+	daily.History = map[string]DayRecord{}
+	daily.ImportedBest = daily.BestStreak
+	if daily.LastCompleted.IsZero() {
+		return
+	}
+	last := logicalDay(daily.LastCompleted)
+	for i := 0; i < max(daily.CurrentStreak, 1); i++ {
+		daily.History[addDays(last, -i)] = DayRecord{Status: dayDone}
+	}
+}
+
+// XP: completions earn points weighted by priority and streak. Every award
+// is kept in AppData.XPLedger so totals can be audited and recomputed.
+
+const (
+	xpStreakCap   = 20 // Streak days at which the bonus stops growing (2x)
+	xpTodoFactor  = 2  // Todos are one-off, so they're worth double
+	xpLevelStep   = 50 // Each level needs this much more XP than the last
+	xpFirstLevel  = 100
+	levelUpLength = 5 * time.Second // How long the header badge flashes
+)
+
+// xpBase is the XP for completing an item of the given priority
+func xpBase(priority string) int {
+	switch normalizePriority(priority) {
+	case "HIGH":
+		return 20
+	case "LOW":
+		return 5
+	}
+	return 10
+}
+
+// xpPoints weighs a completion by priority and the streak it extended,
+// growing linearly to double at xpStreakCap days
+func xpPoints(priority string, streak int) int {
+	base := xpBase(priority)
+	return base + base*min(max(streak-1, 0), xpStreakCap)/xpStreakCap
+}
+
+// awardXP records an award, replacing any earlier one for the same item and
+// day. A new award for today also restores health.
+func awardXP(data *AppData, entry XPEntry) {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	for i, existing := range data.XPLedger {
+		if existing.Source == entry.Source && existing.SourceID == entry.SourceID && existing.Day == entry.Day {
+			entry.Time = existing.Time
+			data.XPLedger[i] = entry
+			return
+		}
+	}
+	data.XPLedger = append(data.XPLedger, entry)
+	if entry.Day == logicalDay(time.Now()) {
+		heal(data, data.Settings.Health.Regen)
+	}
+}
+
+// revokeXP removes the award for an item on day, and the health it restored
+func revokeXP(data *AppData, source string, id int, day string) {
+	kept := data.XPLedger[:0]
+	for _, entry := range data.XPLedger {
+		if entry.Source != source || entry.SourceID != id || entry.Day != day {
+			kept = append(kept, entry)
+		} else if day == logicalDay(time.Now()) {
+			heal(data, -data.Settings.Health.Regen)
+		}
+	}
+	data.XPLedger = kept
+}
+
+// awardDailyXP records XP for a daily done on day, at the streak it had then
+func awardDailyXP(data *AppData, daily Daily, day string) {
+	if daily.Kind == kindAvoid {
+		return
+	}
+	streak := computeStreak(daily, day)
+	awardXP(data, XPEntry{
+		Day:      day,
+		Source:   linkDaily,
+		SourceID: daily.ID,
+		Name:     daily.Task,
+		Priority: normalizePriority(daily.Priority),
+		Streak:   streak,
+		Points:   xpPoints(daily.Priority, streak),
+	})
+}
+
+// awardTodoXP records XP for completing a rolling todo
+func awardTodoXP(data *AppData, todo RollingTodo) {
+	awardXP(data, XPEntry{
+		Day:      logicalDay(time.Now()),
+		Source:   linkTodo,
+		SourceID: todo.ID,
+		Name:     todo.Task,
+		Priority: normalizePriority(todo.Priority),
+		Points:   xpTodoFactor * xpBase(todo.Priority),
+	})
+}
+
+// recomputeXP brings the ledger in line with the dailies as they are now:
+// awards follow edits to name and priority, streaks are re-read from the
+// history, and days no longer marked done lose their award. Entries for
+// deleted dailies, completed todos, quests and penalties are left as earned.
+func recomputeXP(data *AppData) {
+	dailies := dailiesByID(data.Dailies)
+	kept := data.XPLedger[:0]
+	for _, entry := range data.XPLedger {
+		if entry, ok := recomputedEntry(entry, dailies); ok {
+			kept = append(kept, entry)
+		}
+	}
+	data.XPLedger = kept
+}
+
+// recomputedEntry returns a ledger entry as recomputeXP leaves it, or false
+// if the award no longer stands
+func recomputedEntry(entry XPEntry, dailies map[int]Daily) (XPEntry, bool) {
+	switch daily, ok := dailies[entry.SourceID]; {
+	case entry.Source == linkTodo:
+		entry.Points = xpTodoFactor * xpBase(entry.Priority)
+	case entry.Source != linkDaily || !ok:
+	case daily.Kind == kindAvoid || !doneOn(daily, entry.Day):
+		return entry, false
+	default:
+		entry.Name = daily.Task
+		entry.Priority = normalizePriority(daily.Priority)
+		entry.Streak = computeStreak(daily, entry.Day)
+		entry.Points = xpPoints(entry.Priority, entry.Streak)
+	}
+	return entry, true
+}
+
+// dailiesByID indexes dailies by ID
+func dailiesByID(list []Daily) map[int]Daily {
+	dailies := map[int]Daily{}
+	for _, daily := range list {
+		dailies[daily.ID] = daily
+	}
+	return dailies
+}
+
+// totalXP sums the ledger, optionally only awards on or after since
+func totalXP(ledger []XPEntry, since string) int {
+	total := 0
+	for _, entry := range ledger {
+		if entry.Day >= since {
+			total += entry.Points
+		}
+	}
+	return total
+}
+
+// xpForLevel is the total XP needed to reach a level: 0, 100, 250, 450, ...
+func xpForLevel(level int) int {
+	n := level - 1
+	return n*xpFirstLevel + xpLevelStep*n*(n-1)/2
+}
+
+// levelProgress returns the level for a total and the XP into and needed
+// for the next one
+func levelProgress(total int) (level, into, needed int) {
+	level = 1
+	for xpForLevel(level+1) <= total {
+		level++
+	}
+	return level, total - xpForLevel(level), xpForLevel(level+1) - xpForLevel(level)
+}
+
+// xpBar draws progress toward the next level
+func xpBar(into, needed, width int) string {
+	filled := 0
+	if needed > 0 {
+		filled = min(into*width/needed, width)
+	}
+	return strings.Repeat("▰", filled) + strings.Repeat("▱", width-filled)
+}
+
+// checkLevelUp celebrates when the level goes up; XP taken back by undos
+// lowers it quietly
+func (m *model) checkLevelUp(now time.Time) {
+	level, _, _ := levelProgress(totalXP(m.data.XPLedger, ""))
+	if level > m.level && m.level > 0 {
+		m.levelUpAt = now
+		m.pushAnimatedToast(fmt.Sprintf("LEVEL UP! You reached level %d", level))
+	}
+	m.level = level
+}
+
+// levelBadge is the header's level and XP progress, flashing after a level up
+func (m model) levelBadge() string {
+	level, into, needed := levelProgress(totalXP(m.data.XPLedger, ""))
+	badge := fmt.Sprintf("⭐ Lv %d %s %d/%d XP  💰 %d", level, xpBar(into, needed, 10), into, needed, pointsBalance(m.data))
+	if since := m.lastTick.Sub(m.levelUpAt); since >= 0 && since < levelUpLength {
+		frame := int(since / time.Second)
+		return lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(rainbow[frame%len(rainbow)])).Render(badge)
+	}
+	return badge
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExcuseTravelDays(t *testing.T) {
+	t.Cleanup(func() { applyDaySettings(Settings{DayStartHour: defaultDayStartHour}) })
+	utc := func(value string) time.Time {
+		at, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return at
+	}
+
+	tests := []struct {
+		name       string
+		fromZone   string
+		from       time.Time
+		toZone     string
+		to         time.Time
+		wantDay    string // Logical day at `to`
+		wantTravel []string
+	}{
+		{
+			name:     "spring forward is not travel",
+			fromZone: "America/New_York", from: utc("2026-03-08T06:30:00Z"), // 01:30 EST
+			toZone: "America/New_York", to: utc("2026-03-08T07:00:00Z"), // 03:00 EDT
+			wantDay: "2026-03-08",
+		},
+		{
+			name:     "fall back is not travel",
+			fromZone: "America/New_York", from: utc("2026-11-01T05:30:00Z"), // 01:30 EDT
+			toZone: "America/New_York", to: utc("2026-11-01T08:00:00Z"), // 03:00 EST
+			wantDay: "2026-11-01",
+		},
+		{
+			name:     "flying east skips a day",
+			fromZone: "America/New_York", from: utc("2026-03-08T01:00:00Z"), // 20:00 EST on the 7th
+			toZone: "Asia/Tokyo", to: utc("2026-03-08T02:00:00Z"), // 11:00 JST on the 8th
+			wantDay:    "2026-03-08",
+			wantTravel: []string{"2026-03-07"},
+		},
+		{
+			name:     "flying west skips nothing",
+			fromZone: "Asia/Tokyo", from: utc("2026-03-08T02:00:00Z"),
+			toZone: "America/New_York", to: utc("2026-03-08T03:00:00Z"),
+			wantDay: "2026-03-07",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := AppData{Dailies: []Daily{{ID: 1, Task: "read", History: map[string]DayRecord{}}}}
+			applyDaySettings(Settings{DayStartHour: defaultDayStartHour, Timezone: tt.fromZone})
+			excuseTravelDays(&data, tt.from)
+
+			applyDaySettings(Settings{DayStartHour: defaultDayStartHour, Timezone: tt.toZone})
+			travelled := excuseTravelDays(&data, tt.to)
+			if day := logicalDay(tt.to); day != tt.wantDay {
+				t.Errorf("logicalDay = %s, want %s", day, tt.wantDay)
+			}
+			if travelled != (tt.fromZone != tt.toZone) {
+				t.Errorf("excuseTravelDays = %v for %s -> %s", travelled, tt.fromZone, tt.toZone)
+			}
+			var travel []string
+			for day, record := range data.Dailies[0].History {
+				if record.Status == dayTravel {
+					travel = append(travel, day)
+				}
+			}
+			if len(travel) != len(tt.wantTravel) || (len(travel) > 0 && travel[0] != tt.wantTravel[0]) {
+				t.Errorf("travel days = %v, want %v", travel, tt.wantTravel)
+			}
+		})
+	}
+}
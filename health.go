@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Consequences of running out of health, set in settings.health.consequence
+const (
+	consequenceLevel   = "level"   // Drop down a level
+	consequencePoints  = "points"  // Lose settings.health.points_lost shop points
+	consequenceFreezes = "freezes" // Lose every held streak freeze
+)
+
+const (
+	defaultMaxHP = 100
+	xpPenalty    = "penalty" // XPEntry.Source for XP taken by a consequence
+)
+
+// defaultHealth is the health mechanic's out-of-the-box tuning
+func defaultHealth() HealthConfig {
+	return HealthConfig{
+		Enabled:     true,
+		Max:         defaultMaxHP,
+		Damage:      DamageConfig{High: 15, Medium: 10, Low: 5},
+		Regen:       5,
+		Consequence: consequencePoints,
+		PointsLost:  100,
+	}
+}
+
+// damageFor is the HP a missed daily of the given priority costs
+func (c HealthConfig) damageFor(priority string) int {
+	switch normalizePriority(priority) {
+	case "HIGH":
+		return c.Damage.High
+	case "LOW":
+		return c.Damage.Low
+	}
+	return c.Damage.Medium
+}
+
+// missesOn counts how many times a daily counts as missed on day. Weekly and
+// monthly habits are judged on their period's last day and weekly targets on
+// Sunday, like streak freezes. Days before its first record never count.
+func missesOn(daily Daily, day, today string) int {
+	if inactive(daily) || daily.Kind == kindAvoid || day < earliestDay(daily, today) {
+		return 0
+	}
+	s := dailySchedule(daily)
+	if period := s.period(); period != periodDay {
+		start, end := periodStart(day, period), periodEnd(day, period)
+		if day == end && doneInRange(daily, start, end) == 0 && excusedBetween(daily, addDays(start, -1), addDays(end, 1)) == 0 {
+			return 1
+		}
+		return 0
+	}
+	if s.kind == schedulePerWeek {
+		if dayTime(day).Weekday() != time.Sunday {
+			return 0
+		}
+		week := weekStart(day)
+		return max(s.perWeek-doneInRange(daily, week, day)-excusedBetween(daily, addDays(week, -1), addDays(day, 1)), 0)
+	}
+	if missedOn(daily, day) {
+		return 1
+	}
+	return 0
+}
+
+// takeMissDamage charges health for every daily missed on the days from
+// `from` up to (not including) today, after freezes have been spent, and
+// records the recap shown the next morning
+func takeMissDamage(data *AppData, from, today string) {
+	config := data.Settings.Health
+	if !config.Enabled || from == "" || from >= today {
+		return
+	}
+	recap := HealthRecap{Day: today}
+	for day := max(from, addDays(today, -366)); day < today; day = addDays(day, 1) {
+		for _, daily := range data.Dailies {
+			if misses := missesOn(daily, day, today); misses > 0 {
+				damage := misses * config.damageFor(daily.Priority)
+				recap.Damage += damage
+				recap.Misses = append(recap.Misses, fmt.Sprintf("%s -%d", daily.Task, damage))
+			}
+		}
+	}
+	data.Health.Recap = recap
+	data.Health.HP = min(data.Health.HP, config.Max) - recap.Damage
+	if data.Health.HP <= 0 {
+		data.Health.Recap.Consequence = applyConsequence(data)
+		data.Health.HP = config.Max
+	}
+}
+
+// applyConsequence takes the configured penalty for running out of health
+// and describes it
+func applyConsequence(data *AppData) string {
+	config := data.Settings.Health
+	switch config.Consequence {
+	case consequenceLevel:
+		// Drop to the same progress one level down, capped just below the
+		// current level so a level is always lost
+		total := totalXP(data.XPLedger, "")
+		level, into, _ := levelProgress(total)
+		if level <= 1 {
+			return "health ran out, but there was no level to lose"
+		}
+		lost := total - min(xpForLevel(level-1)+into, xpForLevel(level)-1)
+		data.XPLedger = append(data.XPLedger, XPEntry{
+			Time:   time.Now(),
+			Day:    logicalDay(time.Now()),
+			Source: xpPenalty,
+			Name:   "Health ran out",
+			Points: -lost,
+		})
+		return fmt.Sprintf("health ran out: lost a level (-%d XP)", lost)
+	case consequenceFreezes:
+		lost := data.FreezeTokens
+		data.FreezeTokens = 0
+		return fmt.Sprintf("health ran out: lost %d streak freezes", lost)
+	}
+	lost := min(config.PointsLost, max(pointsBalance(*data), 0))
+	data.Transactions = append(data.Transactions, Transaction{
+		ID:     nextID(data.Transactions, func(t Transaction) int { return t.ID }),
+		Time:   time.Now(),
+		Kind:   txPenalty,
+		Name:   "Health ran out",
+		Amount: -lost,
+	})
+	return fmt.Sprintf("health ran out: lost %d points", lost)
+}
+
+// heal restores HP for a completion, or with a negative amount takes it back
+// when one is undone. Undoing never empties the bar.
+func heal(data *AppData, amount int) {
+	config := data.Settings.Health
+	if !config.Enabled {
+		return
+	}
+	data.Health.HP = min(max(data.Health.HP+amount, 1), config.Max)
+}
+
+// hpBadge is the header's health bar, red when low
+func (m model) hpBadge() string {
+	config := m.data.Settings.Health
+	if !config.Enabled || config.Max <= 0 {
+		return ""
+	}
+	hp := min(m.data.Health.HP, config.Max)
+	badge := fmt.Sprintf("❤️ %s %d/%d", xpBar(hp, config.Max, 10), hp, config.Max)
+	if hp*100 < config.Max*30 {
+		return statusOverdueStyle.Render(badge)
+	}
+	return badge
+}
+
+// healthRecap is the Home line for damage taken at this morning's reset
+func (m model) healthRecap() string {
+	recap := m.data.Health.Recap
+	if !m.data.Settings.Health.Enabled || recap.Day != logicalDay(time.Now()) || recap.Damage == 0 {
+		return ""
+	}
+	content := "\n" + statusOverdueStyle.Render(fmt.Sprintf("🩸 Overnight: -%d HP", recap.Damage)) + "\n"
+	content += "  Missed: " + strings.Join(recap.Misses, ", ") + "\n"
+	if recap.Consequence != "" {
+		content += "  " + lipgloss.NewStyle().Bold(true).Render("💀 "+recap.Consequence+", HP refilled") + "\n"
+	}
+	return content
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const heatmapWeeks = 52
+
+// Heatmap cell states
+const (
+	cellEmpty   = iota // Not tracked, rest day or in the future
+	cellExcused        // Skipped, frozen, vacation or travel
+	cellScored         // Due: colored by completion ratio
+)
+
+// heatCell is one day on the heatmap
+type heatCell struct {
+	state int
+	ratio float64 // 0..1 completion, cellScored only
+}
+
+// Greens from no completions to all done, like GitHub contributions
+var heatLevels = []lipgloss.Color{"238", "22", "28", "34", "46"}
+
+// dailyCell scores one daily on one day. Days before its first record and
+// after today are empty.
+func dailyCell(daily Daily, day, today string) heatCell {
+	record := daily.History[day]
+	if daily.Kind == kindAvoid {
+		// Every tracked day is clean unless it slipped
+		switch {
+		case day > today || day < avoidStart(daily, today):
+			return heatCell{state: cellEmpty}
+		case record.Status == daySlipped:
+			return heatCell{state: cellScored}
+		case excusedOn(daily, day):
+			return heatCell{state: cellExcused}
+		}
+		return heatCell{state: cellScored, ratio: 1}
+	}
+	switch {
+	case day > today || day < earliestDay(daily, today):
+		return heatCell{state: cellEmpty}
+	case record.Status == dayDone:
+		return heatCell{state: cellScored, ratio: 1}
+	case excusedOn(daily, day):
+		return heatCell{state: cellExcused}
+	case record.Status == dayPartial && daily.Target > 0:
+		return heatCell{state: cellScored, ratio: min(record.Amount/daily.Target, 1)}
+	case !lastChanceOn(daily, day):
+		// Weekly and monthly habits only count as missed on the period's last day
+		return heatCell{state: cellEmpty}
+	case !dueOn(daily, day):
+		return heatCell{state: cellEmpty}
+	}
+	return heatCell{state: cellScored}
+}
+
+// combinedCell averages the scored dailies on a day
+func combinedCell(dailies []Daily, day, today string) heatCell {
+	total, scored, excused := 0.0, 0, false
+	for _, daily := range dailies {
+		cell := dailyCell(daily, day, today)
+		switch cell.state {
+		case cellScored:
+			total += cell.ratio
+			scored++
+		case cellExcused:
+			excused = true
+		}
+	}
+	switch {
+	case scored > 0:
+		return heatCell{state: cellScored, ratio: total / float64(scored)}
+	case excused:
+		return heatCell{state: cellExcused}
+	}
+	return heatCell{state: cellEmpty}
+}
+
+// render draws a cell as a colored square
+func (c heatCell) render(selected bool) string {
+	glyph, style := "■", lipgloss.NewStyle()
+	switch c.state {
+	case cellEmpty:
+		glyph = "·"
+		style = style.Foreground(lipgloss.Color("236"))
+	case cellExcused:
+		style = style.Foreground(lipgloss.Color("25"))
+	default:
+		level := 0
+		if c.ratio > 0 {
+			level = min(int(c.ratio*3)+1, 4)
+		}
+		style = style.Foreground(heatLevels[level])
+	}
+	if selected {
+		style = style.Background(lipgloss.Color("229"))
+	}
+	return style.Render(glyph)
+}
+
+// heatmapDailies returns the dailies shown: one by ID, or all when ID is 0
+func (m model) heatmapDailies() []Daily {
+	if m.heatmapID == 0 {
+		return unarchived(m.data.Dailies)
+	}
+	for _, daily := range m.data.Dailies {
+		if daily.ID == m.heatmapID {
+			return []Daily{daily}
+		}
+	}
+	return nil
+}
+
+// heatmapCell scores a day for whatever the heatmap is showing
+func (m model) heatmapCell(day, today string) heatCell {
+	if m.heatmapID == 0 {
+		return combinedCell(unarchived(m.data.Dailies), day, today)
+	}
+	dailies := m.heatmapDailies()
+	if len(dailies) == 0 {
+		return heatCell{state: cellEmpty}
+	}
+	return dailyCell(dailies[0], day, today)
+}
+
+// completionRate is the average ratio over scored days from start to today
+func completionRate(dailies []Daily, start, today string) (float64, int) {
+	total, scored := 0.0, 0
+	for day := start; day <= today; day = addDays(day, 1) {
+		if cell := combinedCell(dailies, day, today); cell.state == cellScored {
+			total += cell.ratio
+			scored++
+		}
+	}
+	if scored == 0 {
+		return 0, 0
+	}
+	return total / float64(scored), scored
+}
+
+// openHeatmap shows the heatmap for the selected daily
+func (m *model) openHeatmap() {
+	cursor, ok := m.selectedSingleDaily()
+	if !ok {
+		return
+	}
+	m.showHeatmap = true
+	m.heatmapID = m.data.Dailies[cursor].ID
+	m.heatmapDay = logicalDay(time.Now())
+	m.heatmapPopup = false
+}
+
+// cycleHeatmap moves between "all dailies" and each daily in table order
+func (m *model) cycleHeatmap(step int) {
+	ids := []int{0}
+	for _, daily := range m.data.Dailies {
+		if daily.Archived == m.showArchived {
+			ids = append(ids, daily.ID)
+		}
+	}
+	current := 0
+	for i, id := range ids {
+		if id == m.heatmapID {
+			current = i
+		}
+	}
+	m.heatmapID = ids[(current+step+len(ids))%len(ids)]
+}
+
+func (m model) handleHeatmapKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	today := logicalDay(time.Now())
+	first := addDays(weekStart(today), -7*heatmapWeeks)
+	move := func(days int) {
+		day := addDays(m.heatmapDay, days)
+		if day >= first && day <= today {
+			m.heatmapDay = day
+		}
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		if m.heatmapPopup {
+			m.heatmapPopup = false
+		} else {
+			m.showHeatmap = false
+		}
+	case "enter":
+		m.heatmapPopup = !m.heatmapPopup
+	case "left", "h":
+		move(-7)
+	case "right", "l":
+		move(7)
+	case "up", "k":
+		move(-1)
+	case "down", "j":
+		move(1)
+	case "tab", "]":
+		m.cycleHeatmap(1)
+	case "shift+tab", "[":
+		m.cycleHeatmap(-1)
+	case "a":
+		m.heatmapID = 0
+	}
+	return m, nil
+}
+
+func (m model) heatmapView() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("105"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	dailies := m.heatmapDailies()
+	today := logicalDay(time.Now())
+	first := addDays(weekStart(today), -7*heatmapWeeks)
+
+	title := "📅 All dailies"
+	var stats, slipLine string
+	if m.heatmapID != 0 && len(dailies) == 1 {
+		daily := dailies[0]
+		title = "📅 " + daily.Task
+		stats = fmt.Sprintf("Streak %d, best %d, %s", daily.CurrentStreak, daily.BestStreak, dailySchedule(daily).label())
+		if daily.Kind == kindAvoid {
+			slips := slipDays(daily)
+			stats = fmt.Sprintf("%d days clean, best run %d, %d slips", daily.CurrentStreak, daily.BestStreak, len(slips))
+			// Most recent slips first, with the clean run each one ended
+			for i := len(slips) - 1; i >= max(len(slips)-5, 0); i-- {
+				run := daysBetween(avoidStart(daily, slips[i]), slips[i])
+				if i > 0 {
+					run = daysBetween(slips[i-1], slips[i])
+				}
+				slipLine += fmt.Sprintf(" %s (after %dd)", dayTime(slips[i]).Format("Jan 2"), run)
+			}
+		}
+	}
+	rate30, _ := completionRate(dailies, addDays(today, -29), today)
+	rateYear, days := completionRate(dailies, first, today)
+	if stats != "" {
+		stats += "  •  "
+	}
+	stats += fmt.Sprintf("%.0f%% last 30 days, %.0f%% over %d tracked days", rate30*100, rateYear*100, days)
+
+	lines := []string{titleStyle.Render(title) + dimStyle.Render("  (last 52 weeks)"), dimStyle.Render("  " + stats)}
+	if slipLine != "" {
+		lines = append(lines, dimStyle.Render("  Recent slips:"+slipLine))
+	}
+	lines = append(lines, "")
+
+	// Month labels above the first week each month starts in
+	labels := []rune(strings.Repeat(" ", 2*(heatmapWeeks+1)))
+	lastMonth := time.Month(0)
+	for week := 0; week <= heatmapWeeks; week++ {
+		month := dayTime(addDays(first, 7*week)).Month()
+		if month != lastMonth && 2*week+3 <= len(labels) {
+			copy(labels[2*week:], []rune(month.String()[:3]))
+			lastMonth = month
+		}
+	}
+	lines = append(lines, "      "+dimStyle.Render(string(labels)))
+
+	weekdays := []string{"Mon", "   ", "Wed", "   ", "Fri", "   ", "Sun"}
+	for row := 0; row < 7; row++ {
+		var cells []string
+		for week := 0; week <= heatmapWeeks; week++ {
+			day := addDays(first, 7*week+row)
+			if day > today {
+				break
+			}
+			cells = append(cells, m.heatmapCell(day, today).render(day == m.heatmapDay))
+		}
+		lines = append(lines, "  "+dimStyle.Render(weekdays[row])+" "+strings.Join(cells, " "))
+	}
+
+	legend := "  " + dimStyle.Render("Less ")
+	for level := range heatLevels {
+		legend += lipgloss.NewStyle().Foreground(heatLevels[level]).Render("■") + " "
+	}
+	legend += dimStyle.Render("More   ") + heatCell{state: cellExcused}.render(false) + dimStyle.Render(" excused   · not due")
+	lines = append(lines, "", legend)
+
+	// Selected day: summary line, or every daily's record when the popup is open
+	selected := dayTime(m.heatmapDay).Format("Mon Jan 2, 2006")
+	if !m.heatmapPopup {
+		cell := m.heatmapCell(m.heatmapDay, today)
+		summary := "nothing due"
+		switch cell.state {
+		case cellScored:
+			summary = fmt.Sprintf("%.0f%% done", cell.ratio*100)
+		case cellExcused:
+			summary = "excused"
+		}
+		lines = append(lines, "", fmt.Sprintf("  %s: %s", selected, summary))
+	} else {
+		popup := []string{titleStyle.Render(selected)}
+		for _, daily := range dailies {
+			popup = append(popup, fmt.Sprintf("%-24s %s", daily.Task, dayRecordText(daily, m.heatmapDay)))
+		}
+		box := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("105")).Padding(0, 1)
+		lines = append(lines, "", box.Render(strings.Join(popup, "\n")))
+	}
+
+	return lipgloss.NewStyle().Padding(0, 1).Render(strings.Join(lines, "\n"))
+}
+
+// heatmapSummary is the last four weeks of all dailies in one line, for Home
+func (m model) heatmapSummary() string {
+	today := logicalDay(time.Now())
+	start := addDays(today, -27)
+	var cells []string
+	for day := start; day <= today; day = addDays(day, 1) {
+		cells = append(cells, combinedCell(activeDailies(m.data.Dailies), day, today).render(false))
+	}
+	rate, _ := completionRate(activeDailies(m.data.Dailies), start, today)
+	return fmt.Sprintf("  Last 4 weeks:        %s %.0f%%\n", strings.Join(cells, ""), rate*100)
+}
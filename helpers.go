@@ -3,7 +3,6 @@ package main
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"runtime"
 	"sort"
 	"strconv"
@@ -53,9 +52,9 @@ var (
 			Foreground(lipgloss.Color("86"))
 )
 
-func showStatus(msg string, color string) tea.Cmd {
+func showStatus(msg string, severity toastSeverity) tea.Cmd {
 	return func() tea.Msg {
-		return statusMsg{message: msg, color: color}
+		return statusMsg{message: msg, severity: severity}
 	}
 }
 
@@ -90,50 +89,107 @@ func normalizePriority(priority string) string {
 	}
 }
 
-func parseCountdown(countdownStr string) (time.Time, bool) {
+// nextID returns one past the highest ID in use, so IDs stay unique after deletes
+func nextID[T any](items []T, id func(T) int) int {
+	highest := 0
+	for _, item := range items {
+		highest = max(highest, id(item))
+	}
+	return highest + 1
+}
+
+// newTodoID hands out rolling todo IDs that are never reused, so a new todo
+// can't be mistaken for a completed one in the XP ledger, todo log or quests
+func newTodoID(data *AppData) int {
+	id := max(data.NextTodoID,
+		nextID(data.RollingTodos, func(t RollingTodo) int { return t.ID }),
+		nextID(data.TodoLog, func(r TodoRecord) int { return r.ID }))
+	data.NextTodoID = id + 1
+	return id
+}
+
+// newDailyID hands out daily IDs that are never reused, so a new daily can't
+// inherit a deleted one's XP ledger entries, linked reminders or quests
+func newDailyID(data *AppData) int {
+	id := max(data.NextDailyID, nextID(data.Dailies, func(d Daily) int { return d.ID }))
+	for _, entry := range data.XPLedger {
+		if entry.Source == linkDaily && entry.SourceID >= id {
+			id = entry.SourceID + 1
+		}
+	}
+	data.NextDailyID = id + 1
+	return id
+}
+
+// parseInterval parses the countdown shorthand (30s, 5m, 2h, 1d, 1w) into a duration
+func parseInterval(intervalStr string) (time.Duration, bool) {
 	// Days format (1d, 5d, 20d)
-	if strings.HasSuffix(countdownStr, "d") {
-		dayStr := strings.TrimSuffix(countdownStr, "d")
+	if strings.HasSuffix(intervalStr, "d") {
+		dayStr := strings.TrimSuffix(intervalStr, "d")
 		if days, err := strconv.Atoi(dayStr); err == nil {
-			return time.Now().Add(time.Duration(days) * 24 * time.Hour), true
+			return time.Duration(days) * 24 * time.Hour, true
 		}
 	}
 
 	// Weeks format (1w, 2w)
-	if strings.HasSuffix(countdownStr, "w") {
-		weekStr := strings.TrimSuffix(countdownStr, "w")
+	if strings.HasSuffix(intervalStr, "w") {
+		weekStr := strings.TrimSuffix(intervalStr, "w")
 		if weeks, err := strconv.Atoi(weekStr); err == nil {
-			return time.Now().Add(time.Duration(weeks) * 7 * 24 * time.Hour), true
+			return time.Duration(weeks) * 7 * 24 * time.Hour, true
 		}
 	}
 
 	// Minutes format (1m, 30m, min)
-	if strings.HasSuffix(countdownStr, "m") || strings.HasSuffix(countdownStr, "min") {
-		minStr := strings.TrimSuffix(strings.TrimSuffix(countdownStr, "min"), "m")
+	if strings.HasSuffix(intervalStr, "m") || strings.HasSuffix(intervalStr, "min") {
+		minStr := strings.TrimSuffix(strings.TrimSuffix(intervalStr, "min"), "m")
 		if minutes, err := strconv.Atoi(minStr); err == nil {
-			return time.Now().Add(time.Duration(minutes) * time.Minute), true
+			return time.Duration(minutes) * time.Minute, true
 		}
 	}
 
 	// Hours format (1h, 2h, hr)
-	if strings.HasSuffix(countdownStr, "h") || strings.HasSuffix(countdownStr, "hr") {
-		hourStr := strings.TrimSuffix(strings.TrimSuffix(countdownStr, "hr"), "h")
+	if strings.HasSuffix(intervalStr, "h") || strings.HasSuffix(intervalStr, "hr") {
+		hourStr := strings.TrimSuffix(strings.TrimSuffix(intervalStr, "hr"), "h")
 		if hours, err := strconv.Atoi(hourStr); err == nil {
-			return time.Now().Add(time.Duration(hours) * time.Hour), true
+			return time.Duration(hours) * time.Hour, true
 		}
 	}
 
 	// Seconds format (1s, 30s, sec)
-	if strings.HasSuffix(countdownStr, "s") || strings.HasSuffix(countdownStr, "sec") {
-		secStr := strings.TrimSuffix(strings.TrimSuffix(countdownStr, "sec"), "s")
+	if strings.HasSuffix(intervalStr, "s") || strings.HasSuffix(intervalStr, "sec") {
+		secStr := strings.TrimSuffix(strings.TrimSuffix(intervalStr, "sec"), "s")
 		if seconds, err := strconv.Atoi(secStr); err == nil {
-			return time.Now().Add(time.Duration(seconds) * time.Second), true
+			return time.Duration(seconds) * time.Second, true
 		}
 	}
 
+	return 0, false
+}
+
+func parseCountdown(countdownStr string) (time.Time, bool) {
+	if d, ok := parseInterval(countdownStr); ok {
+		return time.Now().Add(d), true
+	}
 	return time.Time{}, false
 }
 
+func parseYesNo(value string) bool {
+	switch normalizeText(value) {
+	case "y", "yes", "true", "1", "on":
+		return true
+	}
+	return false
+}
+
+// parseVolume reads a 1-100 volume, returning 0 (use default) when blank or invalid
+func parseVolume(value string) int {
+	volume, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(value), "%"))
+	if err != nil || volume <= 0 {
+		return 0
+	}
+	return min(volume, 100)
+}
+
 func parseAlarmTime(alarmStr string) (time.Time, bool) {
 	now := time.Now()
 
@@ -210,139 +266,246 @@ func isWSL() bool {
 	return false
 }
 
-func playNotificationSound() {
-	// Try both mp3 and wav files
-	soundFiles := []string{"assets/notification.mp3", "assets/notification.wav"}
-	var soundFile string
-	for _, file := range soundFiles {
-		if _, err := os.Stat(file); err == nil {
-			soundFile = file
-			break
-		}
+// reminderLevel picks the notification level for a reminder's next firing.
+// Escalating reminders step up with each unacknowledged repeat.
+func reminderLevel(r Reminder) notificationLevel {
+	if !r.Escalate {
+		return levelNormal
 	}
+	switch {
+	case r.NotifyCount >= 3:
+		return levelCritical
+	case r.NotifyCount == 2:
+		return levelElevated
+	default:
+		return levelNormal
+	}
+}
 
-	// If no sound file exists, play system beep
-	if soundFile == "" {
-		if isWSL() {
-			go exec.Command("powershell.exe", "-Command", "[console]::beep(800,200)").Run()
-		} else {
-			go exec.Command("printf", "\a").Run()
+// reminderRepeatDue reports whether an expired, unacknowledged reminder is due
+// to notify again
+func reminderRepeatDue(r Reminder, now time.Time) bool {
+	if r.Status != "expired" || r.Acknowledged || r.LastNotified.IsZero() {
+		return false
+	}
+	every, ok := parseInterval(r.RepeatEvery)
+	if !ok || every <= 0 {
+		return false
+	}
+	return now.Sub(r.LastNotified) >= every
+}
+
+// isRinging reports whether a reminder keeps repeating until acknowledged
+func isRinging(r Reminder) bool {
+	if r.Status != "expired" || r.Acknowledged {
+		return false
+	}
+	every, ok := parseInterval(r.RepeatEvery)
+	return ok && every > 0
+}
+
+// resetNotifyState clears delivery bookkeeping so a re-armed reminder fires
+// again. Call it after TargetTime is set so pre-alerts arm against it.
+func resetNotifyState(r *Reminder) {
+	r.Notified = false
+	r.Acknowledged = false
+	r.NotifyCount = 0
+	r.LastNotified = time.Time{}
+	armPreAlerts(r.PreAlerts, r.TargetTime, time.Now())
+}
+
+// parsePreAlerts reads a comma separated list of offsets ("10m, 1h before, 1d")
+func parsePreAlerts(value string) []PreAlert {
+	var alerts []PreAlert
+	for _, part := range strings.Split(value, ",") {
+		offset := strings.TrimSpace(strings.TrimSuffix(normalizeText(part), "before"))
+		if _, ok := parseInterval(offset); ok {
+			alerts = append(alerts, PreAlert{Offset: offset})
 		}
-		return
 	}
+	return alerts
+}
+
+func formatPreAlerts(alerts []PreAlert) string {
+	offsets := make([]string, len(alerts))
+	for i, alert := range alerts {
+		offsets[i] = alert.Offset
+	}
+	return strings.Join(offsets, ", ")
+}
+
+// armPreAlerts resets pre-alerts against a new target. Alerts whose moment has
+// already passed are marked notified so they don't all fire at once.
+func armPreAlerts(alerts []PreAlert, target, now time.Time) {
+	for i := range alerts {
+		offset, _ := parseInterval(alerts[i].Offset)
+		alerts[i].Notified = target.IsZero() || !now.Before(target.Add(-offset))
+	}
+}
 
-	if isWSL() {
-		// In WSL, just use Linux audio players if available
-		players := [][]string{
-			{"mpv", "--no-video", "--really-quiet", "--audio-buffer=1.0", soundFile},
-			{"vlc", "--intf", "dummy", "--play-and-exit", soundFile},
-			{"mplayer", "-really-quiet", soundFile},
-			{"ffplay", "-nodisp", "-autoexit", "-v", "quiet", soundFile},
+// firePreAlerts marks every pre-alert that has come due as notified and
+// returns the offset of the closest one, so a backlog only notifies once
+func firePreAlerts(alerts []PreAlert, target, now time.Time) (string, bool) {
+	if !now.Before(target) {
+		return "", false
+	}
+	var closest string
+	var closestOffset time.Duration
+	for i := range alerts {
+		offset, ok := parseInterval(alerts[i].Offset)
+		if !ok || alerts[i].Notified || now.Before(target.Add(-offset)) {
+			continue
 		}
-		for _, cmd := range players {
-			if _, err := exec.LookPath(cmd[0]); err == nil {
-				go exec.Command(cmd[0], cmd[1:]...).Run()
-				return
-			}
+		alerts[i].Notified = true
+		if closest == "" || offset < closestOffset {
+			closest, closestOffset = alerts[i].Offset, offset
 		}
-		// If no players available, just beep
-		go exec.Command("powershell.exe", "-Command", "[console]::beep(800,200)").Run()
-		return
-	}
-
-	switch runtime.GOOS {
-	case "linux":
-		// Try different audio players (in order of preference)
-		players := [][]string{
-			{"mpv", "--no-video", "--really-quiet", "--audio-buffer=1.0", soundFile},
-			{"vlc", "--intf", "dummy", "--play-and-exit", soundFile},
-			{"mplayer", "-really-quiet", soundFile},
-			{"ffplay", "-nodisp", "-autoexit", "-v", "quiet", soundFile},
+	}
+	return closest, closest != ""
+}
+
+// preAlerted reports whether any pre-alert has fired for a still-pending target
+func preAlerted(alerts []PreAlert, target, now time.Time) bool {
+	if target.IsZero() || !now.Before(target) {
+		return false
+	}
+	for _, alert := range alerts {
+		offset, ok := parseInterval(alert.Offset)
+		if ok && alert.Notified && !now.Before(target.Add(-offset)) {
+			return true
 		}
-		for _, cmd := range players {
-			if _, err := exec.LookPath(cmd[0]); err == nil {
-				go exec.Command(cmd[0], cmd[1:]...).Run()
-				return
-			}
+	}
+	return false
+}
+
+// parseDeadline reads a todo deadline. Date-only deadlines count as due at
+// 09:00 so "1h before" style pre-alerts land during the day.
+func parseDeadline(deadline string) (time.Time, bool) {
+	deadline = strings.TrimSpace(deadline)
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04", "01/02/2006 15:04"} {
+		if t, err := time.ParseInLocation(layout, deadline, time.Local); err == nil {
+			return t, true
 		}
-	case "darwin":
-		// Use afplay on macOS
-		go exec.Command("afplay", soundFile).Run()
-	case "windows":
-		// Use PowerShell to play sound on Windows
-		go exec.Command("powershell", "-Command", fmt.Sprintf(`(New-Object Media.SoundPlayer "%s").PlaySync()`, soundFile)).Run()
-	}
-}
-
-func sendNotification(title, message string) {
-	// Play notification sound
-	playNotificationSound()
-
-	// Send system notification
-	switch runtime.GOOS {
-	case "linux":
-		if isWSL() {
-			// WSL2: use PowerShell toast notification via Windows interop
-			script := fmt.Sprintf(
-				`[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null; `+
-					`$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent(0); `+
-					`$text = $template.GetElementsByTagName('text'); `+
-					`$text.Item(0).AppendChild($template.CreateTextNode('%s: %s')) > $null; `+
-					`$toast = [Windows.UI.Notifications.ToastNotification]::new($template); `+
-					`[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('lif').Show($toast)`,
-				title, message)
-			exec.Command("powershell.exe", "-Command", script).Run()
-		} else {
-			exec.Command("notify-send", title, message).Run()
+	}
+	for _, layout := range []string{"2006-01-02", "01/02/2006", "Jan 2 2006", "January 2 2006"} {
+		if t, err := time.ParseInLocation(layout, deadline, time.Local); err == nil {
+			return t.Add(9 * time.Hour), true
 		}
-	case "darwin":
-		exec.Command("osascript", "-e", fmt.Sprintf(`display notification "%s" with title "%s"`, message, title)).Run()
-	case "windows":
-		exec.Command("powershell", "-Command", fmt.Sprintf(`[System.Reflection.Assembly]::LoadWithPartialName('System.Windows.Forms'); [System.Windows.Forms.MessageBox]::Show('%s', '%s')`, message, title)).Run()
 	}
+	return time.Time{}, false
 }
 
-func getMostRecent3AM() time.Time {
-	now := time.Now()
-	today3AM := time.Date(now.Year(), now.Month(), now.Day(), 3, 0, 0, 0, now.Location())
+// dailyNudgeLead is how long before a daily's due time the first nudge fires
+const dailyNudgeLead = 30 * time.Minute
 
-	// If current time is before 3AM today, use yesterday's 3AM
-	if now.Before(today3AM) {
-		return today3AM.AddDate(0, 0, -1)
+// parseDailyDeadline reads a daily's due time of day ("21:00", "by 9pm",
+// "9:30 PM") and returns it as "15:04"
+func parseDailyDeadline(value string) (string, bool) {
+	value = strings.TrimSpace(strings.TrimPrefix(normalizeText(value), "by "))
+	for _, layout := range []string{"15:04", "3:04pm", "3:04 pm", "3pm", "3 pm"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Format("15:04"), true
+		}
 	}
+	return "", false
+}
 
-	// If current time is after 3AM today, use today's 3AM
-	return today3AM
+// normalizeDailyDeadline stores recognised due times as "15:04" and leaves
+// anything else as typed
+func normalizeDailyDeadline(value string) string {
+	if clock, ok := parseDailyDeadline(value); ok {
+		return clock
+	}
+	return strings.TrimSpace(value)
 }
 
+// dailyDueTime returns when a daily is due within the logical day that
+// contains now. Times before the day start hour fall at the end of that day.
+func dailyDueTime(daily Daily, now time.Time) (time.Time, bool) {
+	clock, ok := parseDailyDeadline(daily.Deadline)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, _ := time.Parse("15:04", clock)
+	day := dayTime(logicalDay(now))
+	if t.Hour() < dayStartHour {
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, inDayZone(now).Location()), true
+}
+
+// dailyNudgeStage reports how close an incomplete daily is to its due time:
+// 0 not yet, 1 within dailyNudgeLead, 2 at or past due
+func dailyNudgeStage(daily Daily, now time.Time) int {
+	due, ok := dailyDueTime(daily, now)
+	if !ok || daily.Status == "DONE" || daily.Kind == kindAvoid || !dueOn(daily, logicalDay(now)) || excusedOn(daily, logicalDay(now)) || !lastChanceOn(daily, logicalDay(now)) {
+		return 0
+	}
+	switch {
+	case !now.Before(due):
+		return 2
+	case !now.Before(due.Add(-dailyNudgeLead)):
+		return 1
+	}
+	return 0
+}
+
+// lastResetDay is the logical day resetDailyTasks last ran its full pass
+var lastResetDay string
+
+// resetDailyTasks clears DONE on dailies not completed today and recomputes
+// streaks from their history. Streaks are only lost through missed
+// scheduled days, never by the reset itself.
 func resetDailyTasks(data *AppData) bool {
-	mostRecent3AM := getMostRecent3AM()
-	now := time.Now()
-	resetOccurred := false
+	travelled := excuseTravelDays(data, time.Now())
+	today := logicalDay(time.Now())
+	if today == lastResetDay && !travelled {
+		return false
+	}
+	lastResetDay = today
+	previous := data.LastRollover
+	resetOccurred := rollover(data, today) > 0 || data.LastRollover != previous
+	// Misses left after freezes cost health
+	takeMissDamage(data, previous, today)
 
 	for i := range data.Dailies {
 		daily := &data.Dailies[i]
-		// Reset to INCOMPLETE if task was completed before the most recent 3AM
-		if daily.Status == "DONE" && daily.LastCompleted.Before(mostRecent3AM) {
+		if inactive(*daily) {
+			// Paused and archived dailies keep their status and streak
+			continue
+		}
+		if daily.Kind == kindAvoid {
+			// Avoid habits start every day clean
+			status := "DONE"
+			if daily.History[today].Status == daySlipped {
+				status = "SLIPPED"
+			}
+			if daily.Status != status {
+				daily.Status = status
+				resetOccurred = true
+			}
+		} else if daily.Status == "DONE" && !doneInPeriod(*daily, today) {
+			// Weekly and monthly habits stay DONE until their period ends
 			daily.Status = "INCOMPLETE"
 			daily.LastCompleted = time.Time{} // Reset completion time
-			daily.CurrentStreak = 0           // Reset streak
 			resetOccurred = true
 		}
-
-		// Check if streak should be broken (task not completed in previous 3AM-based day)
-		if daily.CurrentStreak > 0 && !daily.LastCompleted.IsZero() {
-			lastCompletedDay := get3AMDay(daily.LastCompleted)
-			yesterdayTime := now.Add(-24 * time.Hour)
-			yesterday := get3AMDay(yesterdayTime)
-
-			// If last completion was not yesterday or today, break the streak
-			if lastCompletedDay != yesterday && lastCompletedDay != get3AMDay(now) {
-				daily.CurrentStreak = 0
-				resetOccurred = true
-			}
+		if streak := computeStreak(*daily, today); streak != daily.CurrentStreak {
+			daily.CurrentStreak = streak
+			daily.BestStreak = max(daily.BestStreak, streak)
+			resetOccurred = true
 		}
 	}
+	// Reminders linked to dailies move on to today's due time
+	for _, daily := range activeDailies(data.Dailies) {
+		if rearmLinked(data, linkDaily, daily.ID) > 0 {
+			resetOccurred = true
+		}
+	}
+	// New quests once streaks are up to date, so broken ones can be picked
+	if refreshQuests(data, today) {
+		resetOccurred = true
+	}
 
 	return resetOccurred
 }
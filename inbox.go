@@ -0,0 +1,283 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	maxNotificationLog = 200              // Oldest entries are dropped past this
+	snoozeDuration     = 10 * time.Minute // How long "snooze" in the inbox defers
+)
+
+// logNotification appends a history entry for n with its backend results
+func (m *model) logNotification(n Notification, results []notifyResult, queued bool) {
+	record := NotificationRecord{
+		ID:         nextID(m.data.NotificationLog, func(r NotificationRecord) int { return r.ID }),
+		Time:       time.Now(),
+		Title:      n.Title,
+		Message:    n.Message,
+		SourceType: n.SourceType,
+		SourceID:   n.SourceID,
+		Results:    backendResults(results),
+		Queued:     queued,
+	}
+	m.data.NotificationLog = append(m.data.NotificationLog, record)
+	if overflow := len(m.data.NotificationLog) - maxNotificationLog; overflow > 0 {
+		m.data.NotificationLog = m.data.NotificationLog[overflow:]
+	}
+}
+
+func backendResults(results []notifyResult) []BackendResult {
+	var out []BackendResult
+	for _, result := range results {
+		entry := BackendResult{Backend: result.Backend}
+		if result.Err != nil {
+			entry.Error = result.Err.Error()
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+// acknowledgeReminder stops a reminder's repeats and marks its history
+// entries. Each alarm counts once towards the acknowledged total, however
+// often it repeated.
+func acknowledgeReminder(data *AppData, reminder *Reminder) {
+	if reminder.Status == "expired" && !reminder.Acknowledged {
+		data.Acknowledged++
+	}
+	reminder.Acknowledged = true
+	for i := range data.NotificationLog {
+		record := &data.NotificationLog[i]
+		if record.SourceType == "reminder" && record.SourceID == reminder.ID {
+			record.Acknowledged = true
+		}
+	}
+}
+
+// inboxRecords returns the history newest first
+func (m *model) inboxRecords() []*NotificationRecord {
+	records := make([]*NotificationRecord, 0, len(m.data.NotificationLog))
+	for i := len(m.data.NotificationLog) - 1; i >= 0; i-- {
+		records = append(records, &m.data.NotificationLog[i])
+	}
+	return records
+}
+
+func (m *model) selectedRecord() *NotificationRecord {
+	records := m.inboxRecords()
+	if m.inboxCursor < 0 || m.inboxCursor >= len(records) {
+		return nil
+	}
+	return records[m.inboxCursor]
+}
+
+func (m model) handleInboxKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	records := m.inboxRecords()
+	switch msg.String() {
+	case "i", "esc", "q":
+		m.showInbox = false
+	case "up", "k":
+		if m.inboxCursor > 0 {
+			m.inboxCursor--
+		}
+	case "down", "j":
+		if m.inboxCursor < len(records)-1 {
+			m.inboxCursor++
+		}
+	case "enter":
+		if record := m.selectedRecord(); record != nil {
+			m.jumpToSource(*record)
+		}
+	case "a":
+		if record := m.selectedRecord(); record != nil {
+			m.acknowledgeRecord(record)
+		}
+	case "r":
+		if record := m.selectedRecord(); record != nil {
+			m.retrigger(*record)
+		}
+	case "s":
+		if record := m.selectedRecord(); record != nil {
+			m.snooze(*record)
+		}
+	case "c":
+		if record := m.selectedRecord(); record != nil && (record.SourceType == "reminder" || record.SourceType == "prealert") {
+			m.completeLinked(record.SourceID)
+		}
+	}
+	return m, nil
+}
+
+// jumpToSource switches to the tab holding the record's source and selects it
+func (m *model) jumpToSource(record NotificationRecord) {
+	m.refreshRows()
+	switch record.SourceType {
+	case "reminder", "prealert", "timer":
+		for i, reminder := range m.data.Reminders {
+			if reminder.ID == record.SourceID {
+				m.showInbox = false
+				m.activeTab = 4
+				m.tables[2].SetCursor(i)
+				return
+			}
+		}
+	case "daily":
+		for i, daily := range m.data.Dailies {
+			if daily.ID == record.SourceID {
+				m.showInbox = false
+				m.activeTab = 2
+				m.tables[0].SetCursor(i)
+				return
+			}
+		}
+	case "todo":
+		for i, todo := range m.data.RollingTodos {
+			if todo.ID == record.SourceID {
+				m.showInbox = false
+				m.activeTab = 3
+				m.tables[1].SetCursor(i)
+				return
+			}
+		}
+	}
+	m.pushToast("⚠️ Source item no longer exists", toastWarning)
+}
+
+func (m *model) acknowledgeRecord(record *NotificationRecord) {
+	record.Acknowledged = true
+	if record.SourceType == "reminder" {
+		for i := range m.data.Reminders {
+			if m.data.Reminders[i].ID == record.SourceID && m.data.Reminders[i].Status == "expired" {
+				acknowledgeReminder(&m.data, &m.data.Reminders[i])
+			}
+		}
+		m.tables[2].SetRows(m.reminderRows())
+	}
+	saveData(m.data)
+	m.pushToast(fmt.Sprintf("✅ Acknowledged: %s", record.Message), toastSuccess)
+	m.achievementEvent(eventReminder)
+}
+
+// retrigger sends a history entry again as a fresh notification
+func (m *model) retrigger(record NotificationRecord) {
+	m.notify(Notification{
+		Title:      record.Title,
+		Message:    record.Message,
+		Level:      levelNormal,
+		SourceType: record.SourceType,
+		SourceID:   record.SourceID,
+	}, false)
+	m.inboxCursor = 0
+	saveData(m.data)
+	m.pushToast(fmt.Sprintf("🔁 Re-sent: %s", record.Message), toastInfo)
+}
+
+// snooze re-arms the source reminder, or creates a countdown reminder for
+// other sources, to fire again after snoozeDuration. A snoozed pre-alert gets
+// its own countdown so the reminder's real alarm time stays put.
+func (m *model) snooze(record NotificationRecord) {
+	now := time.Now()
+	snoozed := false
+	if record.SourceType == "reminder" {
+		for i := range m.data.Reminders {
+			reminder := &m.data.Reminders[i]
+			if reminder.ID == record.SourceID {
+				acknowledgeReminder(&m.data, reminder)
+				reminder.TargetTime = now.Add(snoozeDuration)
+				reminder.PausedRemaining = 0
+				reminder.Status = "active"
+				resetNotifyState(reminder)
+				snoozed = true
+			}
+		}
+	}
+	if !snoozed {
+		m.data.Reminders = append(m.data.Reminders, Reminder{
+			ID:               nextID(m.data.Reminders, func(r Reminder) int { return r.ID }),
+			Reminder:         record.Message,
+			Note:             "snoozed from notifications",
+			AlarmOrCountdown: "10m",
+			Status:           "active",
+			CreatedAt:        now,
+			TargetTime:       now.Add(snoozeDuration),
+			IsCountdown:      true,
+		})
+	}
+	record.Acknowledged = true
+	for i := range m.data.NotificationLog {
+		if m.data.NotificationLog[i].ID == record.ID {
+			m.data.NotificationLog[i].Acknowledged = true
+		}
+	}
+	m.tables[2].SetRows(m.reminderRows())
+	saveData(m.data)
+	m.pushToast(fmt.Sprintf("😴 Snoozed %s: %s", formatDuration(snoozeDuration), record.Message), toastInfo)
+}
+
+func (m model) inboxView() string {
+	availableHeight := m.getContentHeight()
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("105"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+	failStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+
+	records := m.inboxRecords()
+	unread := 0
+	for _, record := range records {
+		if !record.Acknowledged {
+			unread++
+		}
+	}
+
+	lines := []string{titleStyle.Render(fmt.Sprintf("📥 Notifications (%d, %d unacknowledged)", len(records), unread)), ""}
+	if len(records) == 0 {
+		lines = append(lines, dimStyle.Render("  No notifications yet"))
+		return lipgloss.NewStyle().Padding(0, 1).Render(strings.Join(lines, "\n"))
+	}
+
+	// Keep the cursor inside the visible window
+	visible := max(availableHeight-2, 1)
+	start := 0
+	if m.inboxCursor >= visible {
+		start = m.inboxCursor - visible + 1
+	}
+	end := min(start+visible, len(records))
+
+	for i := start; i < end; i++ {
+		record := records[i]
+		marker := "●"
+		if record.Acknowledged {
+			marker = "✓"
+		}
+
+		var backends []string
+		for _, result := range record.Results {
+			if result.Error != "" {
+				backends = append(backends, failStyle.Render(result.Backend+" ✗"))
+			} else {
+				backends = append(backends, result.Backend+" ✓")
+			}
+		}
+		if record.Queued {
+			backends = append(backends, "held (quiet hours)")
+		}
+
+		line := fmt.Sprintf("%s %s  %s: %s", marker, record.Time.Format("Jan 2 15:04"), record.Title, record.Message)
+		if i == m.inboxCursor {
+			line = selectedStyle.Render(line)
+		}
+		if len(backends) > 0 {
+			line += dimStyle.Render("  [") + strings.Join(backends, dimStyle.Render(", ")) + dimStyle.Render("]")
+		}
+		lines = append(lines, " "+line)
+	}
+
+	return lipgloss.NewStyle().Padding(0, 1).Render(strings.Join(lines, "\n"))
+}
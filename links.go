@@ -0,0 +1,327 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+// Link types stored in Reminder.LinkType
+const (
+	linkTodo  = "todo"
+	linkDaily = "daily"
+)
+
+// linkedName returns the task name of the item a reminder is linked to
+func linkedName(data AppData, linkType string, id int) (string, bool) {
+	switch linkType {
+	case linkTodo:
+		for _, todo := range data.RollingTodos {
+			if todo.ID == id {
+				return todo.Task, true
+			}
+		}
+	case linkDaily:
+		for _, daily := range data.Dailies {
+			if daily.ID == id {
+				return daily.Task, true
+			}
+		}
+	}
+	return "", false
+}
+
+// linkedReminderCount counts reminders attached to an item
+func linkedReminderCount(data AppData, linkType string, id int) int {
+	count := 0
+	for _, reminder := range data.Reminders {
+		if reminder.LinkType == linkType && reminder.LinkID == id {
+			count++
+		}
+	}
+	return count
+}
+
+// linkedTarget resolves "2d before" (or "2h before deadline") against the
+// deadline of the todo, or today's due time of the daily, a reminder is linked to
+func linkedTarget(data AppData, r Reminder) (time.Time, bool) {
+	value := normalizeText(r.AlarmOrCountdown)
+	value = strings.TrimSpace(strings.TrimSuffix(value, "deadline"))
+	if !strings.HasSuffix(value, "before") {
+		return time.Time{}, false
+	}
+	offset, ok := parseInterval(strings.TrimSpace(strings.TrimSuffix(value, "before")))
+	if !ok {
+		return time.Time{}, false
+	}
+	switch r.LinkType {
+	case linkTodo:
+		for _, todo := range data.RollingTodos {
+			if todo.ID == r.LinkID {
+				if deadline, ok := parseDeadline(todo.Deadline); ok {
+					return deadline.Add(-offset), true
+				}
+			}
+		}
+	case linkDaily:
+		for _, daily := range data.Dailies {
+			if daily.ID == r.LinkID {
+				if due, ok := dailyDueTime(daily, time.Now()); ok {
+					return due.Add(-offset), true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// rearmLinked moves the reminders linked to an item onto its current
+// deadline or due time and returns how many moved. Reminders already set for
+// that time, timers and paused or stopped reminders are left alone.
+func rearmLinked(data *AppData, linkType string, id int) int {
+	moved := 0
+	for i := range data.Reminders {
+		reminder := &data.Reminders[i]
+		if reminder.LinkType != linkType || reminder.LinkID != id || reminder.Mode != "" ||
+			reminder.Status == "paused" || reminder.Status == "inactive" {
+			continue
+		}
+		target, ok := linkedTarget(*data, *reminder)
+		if !ok || target.Equal(reminder.TargetTime) {
+			continue
+		}
+		reminder.TargetTime = target
+		reminder.IsCountdown = false
+		reminder.Status = "active"
+		resetNotifyState(reminder)
+		moved++
+	}
+	return moved
+}
+
+// removeLinkedReminders deletes or unlinks every reminder attached to an
+// item and returns how many were affected
+func removeLinkedReminders(data *AppData, linkType string, id int, delete bool) int {
+	kept := data.Reminders[:0]
+	affected := 0
+	for _, reminder := range data.Reminders {
+		if reminder.LinkType == linkType && reminder.LinkID == id {
+			affected++
+			if delete {
+				continue
+			}
+			reminder.LinkType = ""
+			reminder.LinkID = 0
+		}
+		kept = append(kept, reminder)
+	}
+	data.Reminders = kept
+	return affected
+}
+
+// addLinkedReminder opens the reminder form attached to the selected daily or todo
+func (m *model) addLinkedReminder() {
+	var linkType, name string
+	var id int
+	switch m.activeTab {
+	case 2: // Dailies
+		cursor, ok := m.selectedSingleDaily()
+		if !ok {
+			return
+		}
+		linkType, id, name = linkDaily, m.data.Dailies[cursor].ID, m.data.Dailies[cursor].Task
+	case 3: // Rolling Todos
+		cursor := m.tables[1].Cursor()
+		if cursor >= len(m.data.RollingTodos) {
+			return
+		}
+		linkType, id, name = linkTodo, m.data.RollingTodos[cursor].ID, m.data.RollingTodos[cursor].Task
+	default:
+		return
+	}
+
+	m.editing = true
+	m.editingTab = 4
+	m.editingRow = -1
+	m.editingField = 2
+	m.linkType = linkType
+	m.linkID = id
+	m.inputs = make([]textinput.Model, 9)
+	for i := range m.inputs {
+		m.inputs[i] = textinput.New()
+	}
+	m.inputs[0].SetValue(name)
+	m.inputs[2].Focus()
+}
+
+// completeDaily marks a daily DONE and updates its streak. A quantitative
+// daily is topped up to its target.
+func completeDaily(data *AppData, daily *Daily) {
+	updateTaskStreak(data, daily)
+	defer updateRoutineStreak(data, *daily)
+	if today := logicalDay(time.Now()); daily.Target > 0 && dayAmount(*daily, today) < daily.Target {
+		record := daily.History[today]
+		record.Amount = daily.Target
+		daily.History[today] = record
+	}
+	daily.LastCompleted = time.Now() // Record completion time
+	daily.Status = "DONE"
+}
+
+// completeTodo removes a rolling todo, records it in the todo log and drops
+// the reminders that were only there to chase it
+func completeTodo(data *AppData, index int) {
+	todo := data.RollingTodos[index]
+	awardTodoXP(data, todo)
+	data.TodoLog = append(data.TodoLog, TodoRecord{
+		ID:        todo.ID,
+		Task:      todo.Task,
+		Category:  todo.Category,
+		Completed: time.Now(),
+	})
+	removeLinkedReminders(data, linkTodo, todo.ID, true)
+	data.RollingTodos = append(data.RollingTodos[:index], data.RollingTodos[index+1:]...)
+}
+
+// completeLinked completes the daily or todo a reminder points at. The
+// reminder is acknowledged; a completed todo takes its reminders with it.
+func (m *model) completeLinked(reminderID int) {
+	var reminder *Reminder
+	for i := range m.data.Reminders {
+		if m.data.Reminders[i].ID == reminderID {
+			reminder = &m.data.Reminders[i]
+		}
+	}
+	if reminder == nil {
+		m.pushToast("⚠️ Reminder no longer exists", toastWarning)
+		return
+	}
+	name, ok := linkedName(m.data, reminder.LinkType, reminder.LinkID)
+	if !ok {
+		m.pushToast(fmt.Sprintf("⚠️ %s is not linked to a task", reminder.Reminder), toastWarning)
+		return
+	}
+
+	if reminder.Status == "expired" {
+		acknowledgeReminder(&m.data, reminder)
+		defer m.achievementEvent(eventReminder)
+	}
+	switch reminder.LinkType {
+	case linkDaily:
+		for i := range m.data.Dailies {
+			daily := &m.data.Dailies[i]
+			if daily.ID == reminder.LinkID {
+				if daily.Kind == kindAvoid {
+					m.pushToast(fmt.Sprintf("⚠️ %s is an avoid habit, it can't be completed", name), toastWarning)
+					return
+				}
+				if daily.Status == "DONE" {
+					m.pushToast(fmt.Sprintf("⚠️ %s is already done today", name), toastWarning)
+					return
+				}
+				completeDaily(&m.data, daily)
+			}
+		}
+	case linkTodo:
+		for i, todo := range m.data.RollingTodos {
+			if todo.ID == reminder.LinkID {
+				completeTodo(&m.data, i)
+				break
+			}
+		}
+	}
+
+	m.refreshRows()
+	saveData(m.data)
+	m.pushToast(fmt.Sprintf("✅ Completed: %s", name), toastSuccess)
+	m.achievementEvent(eventCompletion)
+}
+
+// confirmDoneSelected asks before completing the selected todo, since
+// completing removes it and its reminders
+func (m *model) confirmDoneSelected() {
+	cursor := m.tables[1].Cursor()
+	if cursor < 0 || cursor >= len(m.data.RollingTodos) {
+		return
+	}
+	m.confirmDelete, m.confirmDone = true, true
+	m.deleteTarget = m.data.RollingTodos[cursor].Task
+	m.deleteLinked = 0
+}
+
+// completeSelectedTodo completes the todo under the cursor in Rolling Todos
+func (m *model) completeSelectedTodo() {
+	cursor := m.tables[1].Cursor()
+	if cursor >= len(m.data.RollingTodos) {
+		return
+	}
+	name := m.data.RollingTodos[cursor].Task
+	completeTodo(&m.data, cursor)
+	m.refreshRows()
+	saveData(m.data)
+	m.pushToast(fmt.Sprintf("✅ Completed: %s", name), toastSuccess)
+	m.achievementEvent(eventCompletion)
+}
+
+// deleteSelectedWithReminders deletes the selected daily or todo along with
+// every reminder linked to it
+func (m *model) deleteSelectedWithReminders() {
+	cursor := m.tables[m.activeTab-2].Cursor()
+	switch m.activeTab {
+	case 2: // Dailies
+		if cursor, ok := m.selectedDaily(); ok {
+			removeLinkedReminders(&m.data, linkDaily, m.data.Dailies[cursor].ID, true)
+		}
+	case 3: // Rolling Todos
+		if cursor < len(m.data.RollingTodos) {
+			removeLinkedReminders(&m.data, linkTodo, m.data.RollingTodos[cursor].ID, true)
+		}
+	}
+	m.tables[2].SetRows(m.reminderRows())
+	m.deleteSelected()
+}
+
+// homeReminders lists the reminders shown on Home in display order: expired
+// first, then running ones soonest first
+func (m model) homeReminders() ([]Reminder, []Reminder) {
+	var expired, active []Reminder
+	for _, reminder := range m.data.Reminders {
+		switch {
+		case reminder.Status == "expired":
+			expired = append(expired, reminder)
+		case (reminder.Mode == modeStopwatch || !reminder.TargetTime.IsZero()) && (reminder.Status == "active" || reminder.Status == "paused"):
+			active = append(active, reminder)
+		}
+	}
+
+	// Sort by time remaining (soonest first)
+	sort.Slice(active, func(i, j int) bool {
+		iRemaining := time.Until(active[i].TargetTime)
+		jRemaining := time.Until(active[j].TargetTime)
+
+		// Handle paused reminders - use PausedRemaining for comparison
+		if active[i].Status == "paused" && active[i].PausedRemaining > 0 {
+			iRemaining = active[i].PausedRemaining
+		}
+		if active[j].Status == "paused" && active[j].PausedRemaining > 0 {
+			jRemaining = active[j].PausedRemaining
+		}
+
+		// Sort by remaining time (ascending - soonest first)
+		return iRemaining < jRemaining
+	})
+	return expired, active
+}
+
+// selectedHomeReminder returns the reminder under the Home cursor
+func (m model) selectedHomeReminder() (Reminder, bool) {
+	expired, active := m.homeReminders()
+	all := append(expired, active...)
+	if m.homeCursor < 0 || m.homeCursor >= len(all) {
+		return Reminder{}, false
+	}
+	return all[m.homeCursor], true
+}
@@ -11,36 +11,119 @@ import (
 
 // Data structures
 type Daily struct {
-	ID            int       `json:"id"`
-	Task          string    `json:"task"`
-	Priority      string    `json:"priority"`
-	Category      string    `json:"category"`
-	Deadline      string    `json:"deadline"`
-	Status        string    `json:"status"`
-	LastCompleted time.Time `json:"last_completed"`
-	CurrentStreak int       `json:"current_streak"`
-	BestStreak    int       `json:"best_streak"`
+	ID            int                  `json:"id"`
+	Task          string               `json:"task"`
+	Priority      string               `json:"priority"`
+	Category      string               `json:"category"`
+	Deadline      string               `json:"deadline"` // Due time of day, "21:00"
+	Status        string               `json:"status"`
+	LastCompleted time.Time            `json:"last_completed"`
+	CurrentStreak int                  `json:"current_streak"`
+	BestStreak    int                  `json:"best_streak"`
+	NudgeDay      string               `json:"nudge_day"`               // Logical day of the last due-time nudge
+	NudgeStage    int                  `json:"nudge_stage"`             // 1 = due soon sent, 2 = overdue sent
+	Schedule      string               `json:"schedule"`                // Blank = every day, "mon,wed,fri", "every 2d", "3x/week"
+	History       map[string]DayRecord `json:"history"`                 // Logical day ("2006-01-02") -> record
+	Target        float64              `json:"target,omitempty"`        // Quantitative goal per day, 0 = yes/no
+	Unit          string               `json:"unit,omitempty"`          // "glasses", "pages"
+	Step          float64              `json:"step,omitempty"`          // Amount per +/- press, 0 = 1
+	ImportedBest  int                  `json:"imported_best,omitempty"` // Best streak from before history was kept
+	Kind          string               `json:"kind,omitempty"`          // Blank = build, "avoid" = break a habit
+	Since         string               `json:"since,omitempty"`         // Avoid habits: day tracking began
+	Paused        bool                 `json:"paused,omitempty"`        // Out of daily counts and resets, streak held
+	Archived      bool                 `json:"archived,omitempty"`      // Hidden from the table, history kept
+}
+
+// Day statuses stored in DayRecord.Status
+const (
+	dayDone     = "done"
+	daySkipped  = "skipped"  // Marked not applicable, excused
+	dayFrozen   = "frozen"   // Missed but covered by a streak freeze
+	dayVacation = "vacation" // Inside the vacation range, excused
+	dayTravel   = "travel"   // Skipped over by a timezone change, excused
+	dayPartial  = "partial"  // Some progress logged but target not reached
+	daySlipped  = "slipped"  // Avoid habit slipped, ends its clean run
+	dayPaused   = "paused"   // Daily paused or archived, excused
+)
+
+// DayRecord is what happened to a daily on one day
+type DayRecord struct {
+	Status       string  `json:"status"`
+	EarnedFreeze bool    `json:"earned_freeze,omitempty"` // This completion earned a freeze token
+	Amount       float64 `json:"amount,omitempty"`        // Progress logged on a quantitative daily
+}
+
+// FreezeConfig limits and earns streak freezes
+type FreezeConfig struct {
+	Max       int `json:"max"`        // Tokens that can be held at once
+	EarnEvery int `json:"earn_every"` // Earn one token per this many streak days, 0 = never
+}
+
+// Vacation pauses every daily between two days, inclusive
+type Vacation struct {
+	Start string `json:"start"` // "2006-01-02"
+	End   string `json:"end"`
 }
 
 type RollingTodo struct {
-	ID       int    `json:"id"`
-	Task     string `json:"task"`
-	Priority string `json:"priority"`
-	Category string `json:"category"`
-	Deadline string `json:"deadline"`
+	ID        int        `json:"id"`
+	Task      string     `json:"task"`
+	Priority  string     `json:"priority"`
+	Category  string     `json:"category"`
+	Deadline  string     `json:"deadline"`
+	PreAlerts []PreAlert `json:"pre_alerts"`
+}
+
+// PreAlert is an advance warning that fires Offset before a target time
+type PreAlert struct {
+	Offset   string `json:"offset"` // Interval shorthand (10m, 1h, 1d)
+	Notified bool   `json:"notified"`
 }
 
 type Reminder struct {
-	ID               int           `json:"id"`
-	Reminder         string        `json:"reminder"`
-	Note             string        `json:"note"`
-	AlarmOrCountdown string        `json:"alarm_or_countdown"`
-	Status           string        `json:"status"`
-	CreatedAt        time.Time     `json:"created_at"`
-	TargetTime       time.Time     `json:"target_time"`
-	IsCountdown      bool          `json:"is_countdown"`
-	Notified         bool          `json:"notified"`
-	PausedRemaining  time.Duration `json:"paused_remaining"`
+	ID               int             `json:"id"`
+	Reminder         string          `json:"reminder"`
+	Note             string          `json:"note"`
+	AlarmOrCountdown string          `json:"alarm_or_countdown"`
+	Status           string          `json:"status"`
+	CreatedAt        time.Time       `json:"created_at"`
+	TargetTime       time.Time       `json:"target_time"`
+	IsCountdown      bool            `json:"is_countdown"`
+	Notified         bool            `json:"notified"`
+	PausedRemaining  time.Duration   `json:"paused_remaining"`
+	RepeatEvery      string          `json:"repeat_every"`  // Re-notify interval until acknowledged (e.g. 5m)
+	Escalate         bool            `json:"escalate"`      // Step up bell/urgency/volume on each repeat
+	Acknowledged     bool            `json:"acknowledged"`  // Stops repeats once set
+	NotifyCount      int             `json:"notify_count"`  // Times notified since last (re)start
+	LastNotified     time.Time       `json:"last_notified"` // When the last notification went out
+	Sound            string          `json:"sound"`         // Custom sound file, empty = global default
+	Volume           int             `json:"volume"`        // 1-100, 0 = global default
+	PreAlerts        []PreAlert      `json:"pre_alerts"`    // Advance warnings before TargetTime
+	Urgent           bool            `json:"urgent"`        // Bypasses quiet hours
+	Mode             string          `json:"mode"`          // "stopwatch", "pomodoro" or empty for countdown/alarm
+	StartedAt        time.Time       `json:"started_at"`    // Start of the current running segment
+	Elapsed          time.Duration   `json:"elapsed"`       // Stopwatch time accumulated before StartedAt
+	Laps             []time.Duration `json:"laps"`          // Stopwatch elapsed time at each lap
+	Phase            string          `json:"phase"`         // Pomodoro phase: work, break, long break
+	Cycle            int             `json:"cycle"`         // Pomodoro work sessions completed this run
+	LinkType         string          `json:"link_type"`     // "todo", "daily" or empty
+	LinkID           int             `json:"link_id"`       // ID of the linked todo or daily
+}
+
+// TodoRecord is a completed rolling todo
+type TodoRecord struct {
+	ID        int       `json:"id"`
+	Task      string    `json:"task"`
+	Category  string    `json:"category"`
+	Completed time.Time `json:"completed"`
+}
+
+// PomodoroRecord is one completed pomodoro work session
+type PomodoroRecord struct {
+	ReminderID int       `json:"reminder_id"`
+	Task       string    `json:"task"`
+	Completed  time.Time `json:"completed"`
+	Minutes    int       `json:"minutes"`
 }
 
 type ReferenceItem struct {
@@ -52,20 +135,234 @@ type ReferenceItem struct {
 	Meaning string `json:"meaning"`
 }
 
+// NotifyConfig selects notifier backends per notification level. Backend
+// names: desktop, dbus, bell, command, webhook, file, stdout.
+type NotifyConfig struct {
+	Backends   map[string][]string `json:"backends"`    // Level (normal/elevated/critical) -> backend names
+	Command    []string            `json:"command"`     // Argv template for "command"; {title}, {message}, {level}
+	WebhookURL string              `json:"webhook_url"` // Local URL for "webhook"
+	FilePath   string              `json:"file_path"`   // Log file for "file" ("-" for stdout)
+}
+
+// SoundConfig is the global default notification sound
+type SoundConfig struct {
+	File   string `json:"file"`   // Custom sound file, empty = built-in sound
+	Volume int    `json:"volume"` // 1-100, 0 = 100
+}
+
+// QuietWindow is a daily do-not-disturb window in 24h clock times
+type QuietWindow struct {
+	Start string `json:"start"` // e.g. "22:00"
+	End   string `json:"end"`   // e.g. "07:00", may wrap past midnight
+}
+
+// QuietConfig decides when notifications are held back for a digest
+type QuietConfig struct {
+	Windows  []QuietWindow `json:"windows"`
+	Weekends bool          `json:"weekends"` // Quiet all day Saturday and Sunday
+	Manual   bool          `json:"manual"`   // Do-not-disturb toggled from the TUI
+}
+
+// QueuedNotification is held during quiet hours and delivered in the digest
+type QueuedNotification struct {
+	Time    time.Time `json:"time"`
+	Title   string    `json:"title"`
+	Message string    `json:"message"`
+}
+
+// NotificationRecord is one entry in the notification history
+type NotificationRecord struct {
+	ID           int             `json:"id"`
+	Time         time.Time       `json:"time"`
+	Title        string          `json:"title"`
+	Message      string          `json:"message"`
+	SourceType   string          `json:"source_type"` // "reminder", "prealert", "timer", "todo" or empty
+	SourceID     int             `json:"source_id"`
+	Results      []BackendResult `json:"results"`
+	Queued       bool            `json:"queued"` // Held back for the quiet hours digest
+	Acknowledged bool            `json:"acknowledged"`
+}
+
+// BackendResult is the outcome of one notifier backend; Error is empty on success
+type BackendResult struct {
+	Backend string `json:"backend"`
+	Error   string `json:"error"`
+}
+
+// Settings holds user preferences stored alongside the data
+type Settings struct {
+	Notify NotifyConfig `json:"notify"`
+	Sound  SoundConfig  `json:"sound"`
+	Quiet  QuietConfig  `json:"quiet"`
+
+	Freezes      FreezeConfig `json:"freezes"`
+	DayStartHour int          `json:"day_start_hour"` // Hour (0-23) the day rolls over, default 3
+	Timezone     string       `json:"timezone"`       // IANA zone to pin day boundaries to, blank = local
+	BackfillDays int          `json:"backfill_days"`  // How many days back completions can be edited
+
+	Achievements []AchievementRule `json:"achievements"` // Custom rules added to the built-in ones
+	Health       HealthConfig      `json:"health"`
+}
+
+// AchievementRule unlocks a badge once Metric reaches Threshold. Metrics:
+// streak, completions, todos, perfect_weeks, level, routine_streak,
+// reference and acknowledged.
+type AchievementRule struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Icon        string `json:"icon,omitempty"`
+	Metric      string `json:"metric"`
+	Threshold   int    `json:"threshold"`
+}
+
+// Badge is an unlocked achievement
+type Badge struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Icon     string    `json:"icon"`
+	Unlocked time.Time `json:"unlocked"`
+}
+
+// Reward is something to spend points on in the shop
+type Reward struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Cost int    `json:"cost"`
+}
+
+// Transaction changes the points balance on top of XP earned: a purchase
+// spends (negative Amount). Undone ones stay in the history.
+type Transaction struct {
+	ID       int       `json:"id"`
+	Time     time.Time `json:"time"`
+	Kind     string    `json:"kind"`
+	Name     string    `json:"name"`
+	RewardID int       `json:"reward_id,omitempty"`
+	Amount   int       `json:"amount"`
+	Undone   bool      `json:"undone,omitempty"`
+}
+
+// HealthConfig tunes the health mechanic: misses at the daily reset cost HP,
+// completions restore it, and running out applies Consequence
+type HealthConfig struct {
+	Enabled     bool         `json:"enabled"`
+	Max         int          `json:"max"`
+	Damage      DamageConfig `json:"damage"`
+	Regen       int          `json:"regen"`       // HP restored per completion
+	Consequence string       `json:"consequence"` // "level", "points" or "freezes"
+	PointsLost  int          `json:"points_lost"` // For the "points" consequence
+}
+
+// DamageConfig is the HP a missed daily costs, by priority
+type DamageConfig struct {
+	High   int `json:"high"`
+	Medium int `json:"medium"`
+	Low    int `json:"low"`
+}
+
+// Health is the current HP and what the last reset did to it
+type Health struct {
+	HP    int         `json:"hp"`
+	Recap HealthRecap `json:"recap"`
+}
+
+// HealthRecap is the damage taken at a reset, shown on Home that day
+type HealthRecap struct {
+	Day         string   `json:"day"`
+	Damage      int      `json:"damage"`
+	Misses      []string `json:"misses"`
+	Consequence string   `json:"consequence,omitempty"`
+}
+
+// Quest is one of the day's bonus goals
+type Quest struct {
+	ID       int    `json:"id"`
+	Kind     string `json:"kind"`      // "todo", "habit" or "reference"
+	TargetID int    `json:"target_id"` // Todo, daily or reference entry ID
+	Title    string `json:"title"`
+	Bonus    int    `json:"bonus"` // XP paid on completion
+	Done     bool   `json:"done"`
+}
+
+// QuestBoard is the quests generated at a day boundary, valid for that day
+type QuestBoard struct {
+	Day    string  `json:"day"`
+	Seed   int64   `json:"seed"`
+	Quests []Quest `json:"quests"`
+}
+
+// XPEntry is one XP award in the ledger: a daily done on a day, or a todo
+type XPEntry struct {
+	Time     time.Time `json:"time"`
+	Day      string    `json:"day"`    // Logical day the completion counts for
+	Source   string    `json:"source"` // "daily" or "todo"
+	SourceID int       `json:"source_id"`
+	Name     string    `json:"name"`
+	Priority string    `json:"priority"`
+	Streak   int       `json:"streak,omitempty"` // Streak the completion reached
+	Points   int       `json:"points"`
+}
+
+// RoutineStep is one daily in a routine, with an optional step timer
+type RoutineStep struct {
+	DailyID int `json:"daily_id"`
+	Minutes int `json:"minutes,omitempty"` // Step timer in run mode, 0 = none
+}
+
+// Routine is an ordered group of dailies done together, e.g. a morning routine
+type Routine struct {
+	ID            int           `json:"id"`
+	Name          string        `json:"name"`
+	Window        string        `json:"window,omitempty"` // "06:00-08:00", blank = any time
+	Steps         []RoutineStep `json:"steps"`
+	CurrentStreak int           `json:"current_streak"` // Days every due step was done
+	BestStreak    int           `json:"best_streak"`
+}
+
 type AppData struct {
-	Dailies      []Daily         `json:"dailies"`
-	RollingTodos []RollingTodo   `json:"rolling_todos"`
-	Reminders    []Reminder      `json:"reminders"`
-	Reference    []ReferenceItem `json:"reference"`
+	Dailies         []Daily              `json:"dailies"`
+	RollingTodos    []RollingTodo        `json:"rolling_todos"`
+	Reminders       []Reminder           `json:"reminders"`
+	Reference       []ReferenceItem      `json:"reference"`
+	Settings        Settings             `json:"settings"`
+	QuietQueue      []QueuedNotification `json:"quiet_queue"`
+	NotificationLog []NotificationRecord `json:"notification_log"`
+	PomodoroLog     []PomodoroRecord     `json:"pomodoro_log"`
+	TodoLog         []TodoRecord         `json:"todo_log"`
+	NextTodoID      int                  `json:"next_todo_id"`              // Todo IDs only grow, even after completion
+	NextDailyID     int                  `json:"next_daily_id"`             // Daily IDs only grow, even after deletion
+	Acknowledged    int                  `json:"acknowledged"`              // Expired reminders acknowledged, counted once per alarm
+	LastUTCOffset   *int                 `json:"last_utc_offset,omitempty"` // Seconds east of UTC when last run, to spot travel
+	LastZone        string               `json:"last_zone,omitempty"`       // Timezone when last run; offset changes within it are DST
+	LastRollover    string               `json:"last_rollover"`             // Last logical day processed for freezes and vacation
+	FreezeTokens    int                  `json:"freeze_tokens"`
+	Vacation        Vacation             `json:"vacation"`
+	Routines        []Routine            `json:"routines"`
+	XPLedger        []XPEntry            `json:"xp_ledger"`
+	Achievements    []Badge              `json:"achievements"`
+	Rewards         []Reward             `json:"rewards"`
+	Transactions    []Transaction        `json:"transactions"`
+	Health          Health               `json:"health"`
+	Quests          QuestBoard           `json:"quests"`
 }
 
 type statusMsg struct {
-	message string
-	color   string
+	message  string
+	severity toastSeverity
 }
 
 type tickMsg time.Time
 
+// notificationLevel controls how loudly a notification is delivered
+type notificationLevel int
+
+const (
+	levelNormal   notificationLevel = iota // Desktop popup + sound
+	levelElevated                          // Adds a terminal bell
+	levelCritical                          // Critical urgency + loud sound
+)
+
 type notificationMsg struct {
 	reminder Reminder
 }
@@ -74,7 +371,7 @@ type notificationMsg struct {
 const (
 	minTerminalWidth  = 60 // Minimum usable width
 	minTerminalHeight = 20 // Minimum usable height
-	uiOverhead        = 8  // Header (3) + status (2) + borders (2) + padding (1)
+	uiOverhead        = 10 // Header (3) + status (4, room for stacked toasts) + borders (2) + padding (1)
 )
 
 // Model
@@ -87,14 +384,15 @@ type model struct {
 	editingRow     int
 	editingField   int
 	inputs         []textinput.Model
-	statusMsg      string
-	statusColor    string
-	statusExpiry   time.Time
+	toasts         []toast // Visible status messages, oldest first
+	toastLog       []toast // Scrollback of every message shown
 	width          int
 	height         int
 	lastTick       time.Time
 	confirmDelete  bool
 	deleteTarget   string
+	confirmDone    bool    // The pending confirmation completes a todo rather than deleting it
+	dailyShown     int     // Dailies shown in the table; the rest are hidden past the last row
 	sortColumn     [4]int  // Sort column for each table (Dailies, Rolling, Reminders, Reference)
 	sortAscending  [4]bool // Sort direction for each table
 	searchInput    textinput.Model
@@ -102,19 +400,51 @@ type model struct {
 	filteredRef    []ReferenceItem // Filtered reference items based on search
 	showHelp       bool            // Toggle help screen
 	helpScroll     int             // Help screen scroll position
+	showInbox      bool            // Notification history overlay
+	inboxCursor    int             // Selected entry in the inbox, newest first
+	showToastLog   bool            // Message scrollback popup
+	toastLogScroll int             // Scrollback offset from the newest message
+	linkType       string          // Type of item a new reminder is being attached to
+	linkID         int             // ID of that item
+	homeCursor     int             // Selected reminder on Home
+	deleteLinked   int             // Reminders linked to the item pending deletion
+	showBackfill   bool            // Past-days picker for a daily
+	backfillID     int             // Daily being edited in the picker
+	backfillCursor int             // Days back from today
+	showHeatmap    bool            // Heatmap detail pane
+	heatmapID      int             // Daily shown on the heatmap, 0 = all dailies
+	heatmapDay     string          // Selected day on the heatmap
+	heatmapPopup   bool            // Per-day detail popup open
+	showArchived   bool            // Dailies table lists archived dailies
+	groupByRoutine bool            // Dailies table grouped by routine
+	collapsed      map[int]bool    // Routine IDs collapsed to one row
+	showRoutine    bool            // Routine run mode
+	routineID      int             // Routine being run
+	routineStep    int             // Current step in run mode
+	stepStarted    time.Time       // When the current step began, for its timer
+	stepNotified   bool            // Current step's timer has been announced
+	level          int             // Level last seen, to spot level ups
+	levelUpAt      time.Time       // When the last level up happened, for the header flash
+	showTrophies   bool            // Trophy view with every achievement
+	metricValues   map[string]int  // Achievement metrics as of the last achievement event
+	showShop       bool            // Rewards shop
+	shopCursor     int             // Selected reward
+	shopAdding     bool            // Typing a new reward
+	shopInput      textinput.Model // New reward, "name - cost"
 }
 
 func initialModel() model {
 	m := model{
 		activeTab:     1,
 		data:          loadData(),
-		statusColor:   "86",
 		lastTick:      time.Now(),
 		sortColumn:    [4]int{1, 1, 0, 0},              // Default sort: Priority for Dailies/Rolling, default for others
 		sortAscending: [4]bool{true, true, true, true}, // All ascending by default
 		searchActive:  false,
 		filteredRef:   []ReferenceItem{},
 		showHelp:      false,
+		collapsed:     map[int]bool{},
+		metricValues:  map[string]int{},
 	}
 
 	// Initialize search input
@@ -126,11 +456,21 @@ func initialModel() model {
 	if resetDailyTasks(&m.data) {
 		saveData(m.data)
 	}
+	// Catch up on achievements earned while the TUI wasn't running
+	m.achievementEvent(eventAny)
 
 	m.setupTables()
 	return m
 }
 
+// refreshRows reloads every table's rows from m.data
+func (m *model) refreshRows() {
+	m.tables[0].SetRows(m.dailyRows())
+	m.tables[1].SetRows(m.rollingRows())
+	m.tables[2].SetRows(m.reminderRows())
+	m.tables[3].SetRows(m.referenceRows())
+}
+
 func (m *model) setupTables() {
 	// Calculate dynamic table height (leave space for header, tabs, status)
 	tableHeight := m.height - 10
@@ -141,12 +481,13 @@ func (m *model) setupTables() {
 	// Tab 2: Dailies
 	m.tables[0] = table.New(
 		table.WithColumns([]table.Column{
-			{Title: "Task", Width: 35},
+			{Title: "Task", Width: 31},
 			{Title: "Priority", Width: 12},
 			{Title: "Category", Width: 18},
+			{Title: "Schedule", Width: 16},
 			{Title: "Streak", Width: 15},
 			{Title: "Best", Width: 10},
-			{Title: "Status", Width: 18},
+			{Title: "Status", Width: 22},
 		}),
 		table.WithRows(m.dailyRows()),
 		table.WithFocused(true),
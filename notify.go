@@ -0,0 +1,316 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Notification is a single message handed to notifier backends
+type Notification struct {
+	Title   string
+	Message string
+	Level   notificationLevel
+	Sound   string // Overrides the default sound file
+	Volume  int    // Overrides the default volume
+
+	// Item that triggered the notification, for the history inbox
+	SourceType string // "reminder", "prealert", "timer", "todo" or empty
+	SourceID   int
+}
+
+// Notifier delivers a notification through one backend. Implementations must
+// never splice title or message into script source; values are passed as
+// separate argv entries, environment variables or encoded payloads.
+type Notifier interface {
+	Name() string
+	Notify(n Notification) error
+}
+
+// notifyResult records the outcome of one backend for one notification
+type notifyResult struct {
+	Backend string
+	Err     error
+}
+
+func (l notificationLevel) String() string {
+	switch l {
+	case levelElevated:
+		return "elevated"
+	case levelCritical:
+		return "critical"
+	default:
+		return "normal"
+	}
+}
+
+// defaultNotifyBackends is used for any level missing from the config
+var defaultNotifyBackends = map[string][]string{
+	"normal":   {"desktop"},
+	"elevated": {"desktop", "bell"},
+	"critical": {"desktop", "bell"},
+}
+
+// backendsFor returns the backend names configured for a level
+func (c NotifyConfig) backendsFor(level notificationLevel) []string {
+	if names, ok := c.Backends[level.String()]; ok {
+		return names
+	}
+	return defaultNotifyBackends[level.String()]
+}
+
+// newNotifier builds a backend by name from the notify config
+func newNotifier(name string, cfg NotifyConfig) (Notifier, error) {
+	switch name {
+	case "desktop":
+		return desktopNotifier{}, nil
+	case "dbus":
+		return dbusNotifier{}, nil
+	case "bell":
+		return bellNotifier{out: os.Stdout}, nil
+	case "command":
+		if len(cfg.Command) == 0 {
+			return nil, fmt.Errorf("command backend has no command template")
+		}
+		return commandNotifier{template: cfg.Command}, nil
+	case "webhook":
+		if err := checkLocalURL(cfg.WebhookURL); err != nil {
+			return nil, err
+		}
+		// Never follow redirects: they could send the payload off this machine
+		client := &http.Client{
+			Timeout: 3 * time.Second,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+		return webhookNotifier{url: cfg.WebhookURL, client: client}, nil
+	case "file", "stdout":
+		path := cfg.FilePath
+		if name == "stdout" {
+			path = "-"
+		}
+		return fileNotifier{path: path}, nil
+	default:
+		return nil, fmt.Errorf("unknown notifier backend %q", name)
+	}
+}
+
+// sendNotification plays the notification sound and delivers n through every
+// backend configured for its level
+func sendNotification(settings Settings, n Notification) []notifyResult {
+	// Play notification sound, louder once a reminder has escalated to critical
+	playNotificationSound(settings.Sound, n.Sound, n.Volume, n.Level == levelCritical)
+
+	var results []notifyResult
+	for _, name := range settings.Notify.backendsFor(n.Level) {
+		notifier, err := newNotifier(name, settings.Notify)
+		if err == nil {
+			err = notifier.Notify(n)
+		}
+		results = append(results, notifyResult{Backend: name, Err: err})
+	}
+	return results
+}
+
+// desktopNotifier uses the platform's native popup: notify-send on Linux,
+// osascript on macOS and PowerShell on Windows/WSL
+type desktopNotifier struct{}
+
+func (desktopNotifier) Name() string { return "desktop" }
+
+func (desktopNotifier) Notify(n Notification) error {
+	switch runtime.GOOS {
+	case "linux":
+		if isWSL() {
+			// WSL2: use PowerShell toast notification via Windows interop.
+			// Values travel through the environment; WSLENV forwards them.
+			script := `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null; ` +
+				`$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent(0); ` +
+				`$text = $template.GetElementsByTagName('text'); ` +
+				`$text.Item(0).AppendChild($template.CreateTextNode($env:LIF_TITLE + ': ' + $env:LIF_MESSAGE)) > $null; ` +
+				`$toast = [Windows.UI.Notifications.ToastNotification]::new($template); ` +
+				`[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('lif').Show($toast)`
+			cmd := exec.Command("powershell.exe", "-NoProfile", "-Command", script)
+			cmd.Env = notificationEnv(n)
+			wslenv := "LIF_TITLE/u:LIF_MESSAGE/u"
+			if existing := os.Getenv("WSLENV"); existing != "" {
+				wslenv = existing + ":" + wslenv
+			}
+			cmd.Env = append(cmd.Env, "WSLENV="+wslenv)
+			return cmd.Run()
+		}
+		args := []string{"-a", "lif"}
+		if n.Level == levelCritical {
+			args = append(args, "-u", "critical")
+		}
+		// "--" keeps a title starting with "-" from being read as a flag
+		args = append(args, "--", n.Title, n.Message)
+		return exec.Command("notify-send", args...).Run()
+	case "darwin":
+		// AppleScript reads the values from argv rather than the script text
+		return exec.Command("osascript",
+			"-e", "on run argv",
+			"-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+			"-e", "end run",
+			n.Title, n.Message).Run()
+	case "windows":
+		script := `[System.Reflection.Assembly]::LoadWithPartialName('System.Windows.Forms') > $null; ` +
+			`[System.Windows.Forms.MessageBox]::Show($env:LIF_MESSAGE, $env:LIF_TITLE)`
+		cmd := exec.Command("powershell", "-NoProfile", "-Command", script)
+		cmd.Env = notificationEnv(n)
+		return cmd.Run()
+	}
+	return fmt.Errorf("desktop notifications unsupported on %s", runtime.GOOS)
+}
+
+func notificationEnv(n Notification) []string {
+	return append(os.Environ(),
+		"LIF_TITLE="+n.Title,
+		"LIF_MESSAGE="+n.Message,
+		"LIF_LEVEL="+n.Level.String(),
+	)
+}
+
+// dbusNotifier calls org.freedesktop.Notifications.Notify over the session
+// bus directly, without notify-send or any other helper binary
+type dbusNotifier struct{}
+
+func (dbusNotifier) Name() string { return "dbus" }
+
+func (dbusNotifier) Notify(n Notification) error {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	urgency := byte(1)
+	if n.Level == levelCritical {
+		urgency = 2
+	}
+	hints := map[string]dbus.Variant{"urgency": dbus.MakeVariant(urgency)}
+	obj := conn.Object("org.freedesktop.Notifications", "/org/freedesktop/Notifications")
+	return obj.Call("org.freedesktop.Notifications.Notify", 0,
+		"lif", uint32(0), "", n.Title, n.Message, []string{}, hints, int32(-1)).Err
+}
+
+// bellNotifier rings the terminal bell
+type bellNotifier struct {
+	out io.Writer
+}
+
+func (bellNotifier) Name() string { return "bell" }
+
+func (b bellNotifier) Notify(Notification) error {
+	_, err := io.WriteString(b.out, "\a")
+	return err
+}
+
+// commandNotifier runs a user-supplied argv template. Placeholders are
+// substituted per argument after splitting, so no shell ever sees the values.
+type commandNotifier struct {
+	template []string
+}
+
+func (commandNotifier) Name() string { return "command" }
+
+func (c commandNotifier) Notify(n Notification) error {
+	replacer := strings.NewReplacer(
+		"{title}", n.Title,
+		"{message}", n.Message,
+		"{level}", n.Level.String(),
+	)
+	argv := make([]string, len(c.template))
+	for i, arg := range c.template {
+		argv[i] = replacer.Replace(arg)
+	}
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Env = notificationEnv(n)
+	return cmd.Run()
+}
+
+// webhookNotifier POSTs a JSON payload to a local HTTP endpoint
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (webhookNotifier) Name() string { return "webhook" }
+
+func (w webhookNotifier) Notify(n Notification) error {
+	payload, err := json.Marshal(map[string]string{
+		"title":   n.Title,
+		"message": n.Message,
+		"level":   n.Level.String(),
+		"time":    time.Now().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := w.client.Post(w.url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// checkLocalURL only allows webhooks to loopback addresses
+func checkLocalURL(raw string) error {
+	if raw == "" {
+		return fmt.Errorf("webhook backend has no url")
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid webhook url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("webhook url must be http(s): %s", raw)
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("webhook url must point at localhost: %s", raw)
+}
+
+// fileNotifier appends one line per notification to a file, or writes to
+// stdout when the path is empty or "-". Handy for tests and scripting.
+type fileNotifier struct {
+	path string
+}
+
+func (fileNotifier) Name() string { return "file" }
+
+func (f fileNotifier) Notify(n Notification) error {
+	line := fmt.Sprintf("%s [%s] %s: %s\n", time.Now().Format(time.RFC3339), n.Level, n.Title, n.Message)
+	if f.path == "" || f.path == "-" {
+		_, err := io.WriteString(os.Stdout, line)
+		return err
+	}
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.WriteString(file, line)
+	return err
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseTarget reads a quantitative target such as "8 glasses", "10k steps"
+// or "30 pages +5", where "+5" is the amount each +/- press changes. Blank
+// means a plain yes/no daily.
+func parseTarget(value string) (target float64, unit string, step float64, ok bool) {
+	fields := strings.Fields(normalizeText(value))
+	if len(fields) == 0 {
+		return 0, "", 0, true
+	}
+	target, ok = parseAmount(fields[0])
+	if !ok || target <= 0 {
+		return 0, "", 0, false
+	}
+	step = 1
+	var words []string
+	for _, field := range fields[1:] {
+		if strings.HasPrefix(field, "+") {
+			step, ok = parseAmount(strings.TrimPrefix(field, "+"))
+			if !ok || step <= 0 {
+				return 0, "", 0, false
+			}
+			continue
+		}
+		words = append(words, field)
+	}
+	return target, strings.Join(words, " "), step, true
+}
+
+// parseAmount reads "8", "2.5" or "10k"
+func parseAmount(value string) (float64, bool) {
+	multiplier := 1.0
+	if strings.HasSuffix(value, "k") {
+		multiplier = 1000
+		value = strings.TrimSuffix(value, "k")
+	}
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil || amount < 0 {
+		return 0, false
+	}
+	return amount * multiplier, true
+}
+
+// formatAmount prints an amount without trailing zeros
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', -1, 64)
+}
+
+// formatTarget is the inverse of parseTarget, for the edit form
+func formatTarget(daily Daily) string {
+	if daily.Target <= 0 {
+		return ""
+	}
+	text := formatAmount(daily.Target)
+	if daily.Unit != "" {
+		text += " " + daily.Unit
+	}
+	if daily.Step > 0 && daily.Step != 1 {
+		text += " +" + formatAmount(daily.Step)
+	}
+	return text
+}
+
+// dayAmount is how much of a quantitative daily was logged on day
+func dayAmount(daily Daily, day string) float64 {
+	return daily.History[day].Amount
+}
+
+// progressText shows "▰▰▰▱▱▱ 3/8 glasses" for today's progress
+func progressText(daily Daily, amount float64) string {
+	const cells = 6
+	filled := min(int(amount/daily.Target*cells), cells)
+	text := fmt.Sprintf("%s%s %s/%s", strings.Repeat("▰", filled), strings.Repeat("▱", cells-filled),
+		formatAmount(amount), formatAmount(daily.Target))
+	if daily.Unit != "" {
+		text += " " + daily.Unit
+	}
+	return text
+}
+
+// setAmount records today's amount for a quantitative daily. Reaching the
+// target completes it, dropping back below the target undoes the completion.
+func setAmount(data *AppData, daily *Daily, amount float64) {
+	amount = max(amount, 0)
+	today := logicalDay(time.Now())
+	reached := amount >= daily.Target
+
+	switch {
+	case reached && daily.Status != "DONE":
+		completeDaily(data, daily)
+	case !reached && daily.Status == "DONE":
+		undoTaskStreak(data, daily)
+		daily.LastCompleted = time.Time{}
+		daily.Status = "INCOMPLETE"
+	}
+
+	if daily.History == nil {
+		daily.History = map[string]DayRecord{}
+	}
+	record := daily.History[today]
+	if record.Status == "" {
+		record.Status = dayPartial
+	}
+	record.Amount = amount
+	if record.Status == dayPartial && amount == 0 {
+		delete(daily.History, today)
+		return
+	}
+	daily.History[today] = record
+}
+
+// adjustSelected adds (or with a negative sign, removes) one step of
+// progress on the selected quantitative daily
+func (m *model) adjustSelected(sign float64) {
+	cursor, ok := m.selectedSingleDaily()
+	if !ok {
+		return
+	}
+
+	daily := &m.data.Dailies[cursor]
+	if daily.Target <= 0 {
+		m.pushToast(fmt.Sprintf("⚠️ %s has no target, use space to complete it", daily.Task), toastWarning)
+		return
+	}
+
+	wasDone := daily.Status == "DONE"
+	step := daily.Step
+	if step <= 0 {
+		step = 1
+	}
+	amount := dayAmount(*daily, logicalDay(time.Now())) + sign*step
+	if err := checkSpendable(m.data, amountLoss(m.data, *daily, amount)); err != nil {
+		m.pushToast("⚠️ "+err.Error(), toastWarning)
+		return
+	}
+	setAmount(&m.data, daily, amount)
+
+	switch {
+	case daily.Status == "DONE" && !wasDone:
+		m.pushToast(fmt.Sprintf("✅ Target reached: %s! %d day streak! 🔥", daily.Task, daily.CurrentStreak), toastSuccess)
+	case daily.Status != "DONE" && wasDone:
+		m.pushToast(fmt.Sprintf("Below target again: %s", daily.Task), toastWarning)
+	default:
+		m.pushToast(fmt.Sprintf("%s: %s", daily.Task, progressText(*daily, dayAmount(*daily, logicalDay(time.Now())))), toastInfo)
+	}
+	m.tables[0].SetRows(m.dailyRows())
+	saveData(m.data)
+	m.achievementEvent(eventCompletion)
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Quest kinds stored in Quest.Kind
+const (
+	questTodo      = "todo"      // Finish the oldest HIGH priority rolling todo
+	questHabit     = "habit"     // Complete a daily whose streak broke
+	questReference = "reference" // Look up a reference entry in the Reference tab
+)
+
+const xpQuest = "quest" // XPEntry.Source for quest bonuses
+
+// Bonus XP per quest kind
+var questBonus = map[string]int{questTodo: 30, questHabit: 20, questReference: 10}
+
+// questSeed is the default seed for a day, so a day's quests are the same
+// however often they're generated
+func questSeed(day string) int64 {
+	return dayTime(day).Unix() / 86400
+}
+
+// generateQuests picks up to three quests for day from the data: the oldest
+// HIGH priority todo, a due daily with a broken streak and a reference entry
+// to review. The same data, day and seed always give the same quests.
+func generateQuests(data AppData, day string, seed int64) []Quest {
+	rng := rand.New(rand.NewSource(seed))
+	var quests []Quest
+	add := func(kind string, target int, title string) {
+		quests = append(quests, Quest{ID: len(quests) + 1, Kind: kind, TargetID: target, Title: title, Bonus: questBonus[kind]})
+	}
+
+	// Todos have no creation time, but IDs only grow
+	var oldest *RollingTodo
+	for i, todo := range data.RollingTodos {
+		if normalizePriority(todo.Priority) == "HIGH" && (oldest == nil || todo.ID < oldest.ID) {
+			oldest = &data.RollingTodos[i]
+		}
+	}
+	if oldest != nil {
+		add(questTodo, oldest.ID, "Finish "+oldest.Task)
+	}
+
+	var broken []Daily
+	for _, daily := range activeDailies(data.Dailies) {
+		if daily.Kind != kindAvoid && daily.CurrentStreak == 0 && daily.BestStreak > 0 && dueOn(daily, day) {
+			broken = append(broken, daily)
+		}
+	}
+	sort.Slice(broken, func(i, j int) bool { return broken[i].ID < broken[j].ID })
+	if len(broken) > 0 {
+		daily := broken[rng.Intn(len(broken))]
+		add(questHabit, daily.ID, fmt.Sprintf("Restart %s (best was %d)", daily.Task, daily.BestStreak))
+	}
+
+	if len(data.Reference) > 0 {
+		refs := append([]ReferenceItem{}, data.Reference...)
+		sort.Slice(refs, func(i, j int) bool { return refs[i].ID < refs[j].ID })
+		item := refs[rng.Intn(len(refs))]
+		add(questReference, item.ID, fmt.Sprintf("Review %s %s", item.Lang, item.Command))
+	}
+	return quests
+}
+
+// refreshQuests replaces yesterday's quests with a fresh board for today.
+// Returns true if it did.
+func refreshQuests(data *AppData, today string) bool {
+	if data.Quests.Day == today {
+		return false
+	}
+	seed := questSeed(today)
+	data.Quests = QuestBoard{Day: today, Seed: seed, Quests: generateQuests(*data, today, seed)}
+	return true
+}
+
+// questDone reports whether a quest's goal has been met today
+func (m *model) questDone(quest Quest, today string) bool {
+	switch quest.Kind {
+	case questTodo:
+		for _, record := range m.data.TodoLog {
+			if record.ID == quest.TargetID && logicalDay(record.Completed) == today {
+				return true
+			}
+		}
+	case questHabit:
+		for _, daily := range m.data.Dailies {
+			if daily.ID == quest.TargetID {
+				return doneOn(daily, today)
+			}
+		}
+	case questReference:
+		if item, ok := m.selectedReference(); ok && m.activeTab == 5 && !m.editing {
+			return item.ID == quest.TargetID
+		}
+	}
+	return false
+}
+
+// selectedReference is the reference entry under the cursor
+func (m *model) selectedReference() (ReferenceItem, bool) {
+	items := m.data.Reference
+	if m.searchActive && m.searchInput.Value() != "" {
+		items = m.filteredRef
+	}
+	cursor := m.tables[3].Cursor()
+	if cursor >= len(items) {
+		return ReferenceItem{}, false
+	}
+	return items[cursor], true
+}
+
+// checkQuests completes today's quests whose goals are met and pays out
+// their bonus XP
+func (m *model) checkQuests() {
+	today := logicalDay(time.Now())
+	if m.data.Quests.Day != today {
+		return
+	}
+	changed := false
+	for i := range m.data.Quests.Quests {
+		quest := &m.data.Quests.Quests[i]
+		if quest.Done || !m.questDone(*quest, today) {
+			continue
+		}
+		quest.Done = true
+		awardXP(&m.data, XPEntry{
+			Day:      today,
+			Source:   xpQuest,
+			SourceID: quest.ID,
+			Name:     "Quest: " + quest.Title,
+			Points:   quest.Bonus,
+		})
+		m.pushAnimatedToast(fmt.Sprintf("Quest complete: %s, +%d XP", quest.Title, quest.Bonus))
+		changed = true
+	}
+	if changed {
+		saveData(m.data)
+		// Bonus XP can reach a level achievement
+		m.achievementEvent(eventCompletion)
+	}
+}
+
+// questSummary is the quest list at the top of Home
+func (m model) questSummary() string {
+	board := m.data.Quests
+	if board.Day != logicalDay(time.Now()) || len(board.Quests) == 0 {
+		return ""
+	}
+	var lines []string
+	for _, quest := range board.Quests {
+		mark := "☐"
+		if quest.Done {
+			mark = statusDoneStyle.Render("☑")
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s (+%d XP)", mark, quest.Title, quest.Bonus))
+	}
+	untilReset := time.Until(nextDayStart(time.Now())).Truncate(time.Minute)
+	return statusDoneStyle.Render(fmt.Sprintf("🎯 Today's Quests (expire in %s)", formatDuration(untilReset))) + "\n" + strings.Join(lines, "\n") + "\n"
+}
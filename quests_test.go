@@ -0,0 +1,79 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func questData() AppData {
+	data := AppData{
+		RollingTodos: []RollingTodo{
+			{ID: 7, Task: "file taxes", Priority: "HIGH"},
+			{ID: 3, Task: "call bank", Priority: "HIGH"},
+			{ID: 1, Task: "buy socks", Priority: "LOW"},
+		},
+		Reference: initializeReference(),
+	}
+	for id := 1; id <= 6; id++ {
+		data.Dailies = append(data.Dailies, Daily{ID: id, Task: "habit", BestStreak: id, History: map[string]DayRecord{}})
+	}
+	// Not eligible: an avoid habit, a running streak and one never kept up
+	data.Dailies = append(data.Dailies,
+		Daily{ID: 7, Task: "no soda", Kind: kindAvoid, BestStreak: 4, History: map[string]DayRecord{}},
+		Daily{ID: 8, Task: "stretch", CurrentStreak: 2, BestStreak: 2, History: map[string]DayRecord{}},
+		Daily{ID: 9, Task: "journal", History: map[string]DayRecord{}},
+	)
+	return data
+}
+
+func TestGenerateQuests(t *testing.T) {
+	const day = "2026-10-20"
+	data := questData()
+	references := map[int]bool{}
+	for _, item := range data.Reference {
+		references[item.ID] = true
+	}
+
+	tests := []struct {
+		name string
+		seed int64
+	}{
+		{name: "fixed seed", seed: 42},
+		{name: "date seed", seed: questSeed(day)},
+		{name: "next day's seed", seed: questSeed(addDays(day, 1))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quests := generateQuests(questData(), day, tt.seed)
+			if again := generateQuests(questData(), day, tt.seed); !reflect.DeepEqual(quests, again) {
+				t.Fatalf("quests differ for the same seed:\n%+v\n%+v", quests, again)
+			}
+			if len(quests) != 3 {
+				t.Fatalf("got %d quests, want 3", len(quests))
+			}
+
+			for _, quest := range quests {
+				if quest.Bonus <= 0 {
+					t.Errorf("%s quest pays %d XP, want a positive bonus", quest.Kind, quest.Bonus)
+				}
+				switch quest.Kind {
+				case questTodo:
+					if quest.TargetID != 3 {
+						t.Errorf("todo quest targets %d, want the oldest HIGH todo 3", quest.TargetID)
+					}
+				case questHabit:
+					if quest.TargetID < 1 || quest.TargetID > 6 {
+						t.Errorf("habit quest targets %d, want a daily with a broken streak (1-6)", quest.TargetID)
+					}
+				case questReference:
+					if !references[quest.TargetID] {
+						t.Errorf("reference quest targets %d, which isn't a reference entry", quest.TargetID)
+					}
+				default:
+					t.Errorf("unknown quest kind %q", quest.Kind)
+				}
+			}
+		})
+	}
+}
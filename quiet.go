@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// inWindow reports whether now's clock time falls inside the window. Windows
+// whose end is before their start wrap past midnight (22:00-07:00).
+func (w QuietWindow) inWindow(now time.Time) bool {
+	start, err1 := time.Parse("15:04", w.Start)
+	end, err2 := time.Parse("15:04", w.End)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	minute := now.Hour()*60 + now.Minute()
+	startMin := start.Hour()*60 + start.Minute()
+	endMin := end.Hour()*60 + end.Minute()
+	if startMin <= endMin {
+		return minute >= startMin && minute < endMin
+	}
+	return minute >= startMin || minute < endMin
+}
+
+// active reports whether notifications should be held back right now
+func (q QuietConfig) active(now time.Time) bool {
+	if q.Manual {
+		return true
+	}
+	if q.Weekends && (now.Weekday() == time.Saturday || now.Weekday() == time.Sunday) {
+		return true
+	}
+	for _, window := range q.Windows {
+		if window.inWindow(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// notify delivers n unless quiet hours are on, in which case it is queued for
+// the end-of-quiet digest. Urgent notifications always go through. Either way
+// the notification is recorded in the history log.
+func (m *model) notify(n Notification, urgent bool) []notifyResult {
+	now := time.Now()
+	if !urgent && m.data.Settings.Quiet.active(now) {
+		m.data.QuietQueue = append(m.data.QuietQueue, QueuedNotification{
+			Time:    now,
+			Title:   n.Title,
+			Message: n.Message,
+		})
+		m.logNotification(n, nil, true)
+		return nil
+	}
+	results := sendNotification(m.data.Settings, n)
+	m.logNotification(n, results, false)
+	for _, r := range results {
+		if r.Err != nil {
+			m.pushToast(fmt.Sprintf("⚠️ %s notification failed: %v", r.Backend, r.Err), toastError)
+		}
+	}
+	return results
+}
+
+// deliverQuietDigest sends everything queued during quiet hours as a single
+// notification once the quiet window is over. Returns true if one was sent.
+func (m *model) deliverQuietDigest(now time.Time) bool {
+	if len(m.data.QuietQueue) == 0 || m.data.Settings.Quiet.active(now) {
+		return false
+	}
+
+	// Collapse repeats of the same message into one line with a count
+	var order []string
+	counts := map[string]int{}
+	for _, queued := range m.data.QuietQueue {
+		line := fmt.Sprintf("%s: %s", queued.Title, queued.Message)
+		if counts[line] == 0 {
+			order = append(order, line)
+		}
+		counts[line]++
+	}
+	var lines []string
+	for _, line := range order {
+		if counts[line] > 1 {
+			line = fmt.Sprintf("%s (x%d)", line, counts[line])
+		}
+		lines = append(lines, line)
+	}
+
+	results := sendNotification(m.data.Settings, Notification{
+		Title:   fmt.Sprintf("%d notifications during quiet hours", len(m.data.QuietQueue)),
+		Message: strings.Join(lines, "\n"),
+		Level:   levelNormal,
+	})
+	m.data.QuietQueue = nil
+
+	// The held history entries were delivered through the digest
+	for i := range m.data.NotificationLog {
+		if record := &m.data.NotificationLog[i]; record.Queued {
+			record.Queued = false
+			record.Results = backendResults(results)
+		}
+	}
+	return true
+}
@@ -0,0 +1,536 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// parseWindow reads a routine time window such as "06:00-08:00" or
+// "6am-8am" and returns it as "06:00-08:00". Blank means no window.
+func parseWindow(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", true
+	}
+	start, end, found := strings.Cut(value, "-")
+	if !found {
+		return "", false
+	}
+	startClock, ok := parseDailyDeadline(start)
+	if !ok {
+		return "", false
+	}
+	endClock, ok := parseDailyDeadline(end)
+	if !ok {
+		return "", false
+	}
+	return startClock + "-" + endClock, true
+}
+
+// inWindow reports whether now falls inside a routine's time window.
+// Windows may wrap past midnight.
+func (r Routine) inWindow(now time.Time) bool {
+	start, end, found := strings.Cut(r.Window, "-")
+	if !found {
+		return false
+	}
+	clock := inDayZone(now).Format("15:04")
+	if start <= end {
+		return clock >= start && clock < end
+	}
+	return clock >= start || clock < end
+}
+
+// routineOf returns the routine a daily belongs to and its step index
+func routineOf(data *AppData, dailyID int) (*Routine, int) {
+	for i := range data.Routines {
+		for step, item := range data.Routines[i].Steps {
+			if item.DailyID == dailyID {
+				return &data.Routines[i], step
+			}
+		}
+	}
+	return nil, -1
+}
+
+// routineDaily finds a routine step's daily; archived dailies are left out
+func routineDaily(data *AppData, step RoutineStep) (*Daily, bool) {
+	for i := range data.Dailies {
+		if data.Dailies[i].ID == step.DailyID && !data.Dailies[i].Archived {
+			return &data.Dailies[i], true
+		}
+	}
+	return nil, false
+}
+
+// routineProgress counts done and total steps of a routine for today
+func routineProgress(data *AppData, r Routine, today string) (int, int) {
+	done, total := 0, 0
+	for _, step := range r.Steps {
+		daily, ok := routineDaily(data, step)
+		if !ok || daily.Paused {
+			continue
+		}
+		total++
+		if doneOn(*daily, today) || (daily.Kind == kindAvoid && daily.History[today].Status != daySlipped) {
+			done++
+		}
+	}
+	return done, total
+}
+
+// routineCompletion is today's routineProgress for a routine
+func (m *model) routineCompletion(r Routine) (int, int) {
+	return routineProgress(&m.data, r, logicalDay(time.Now()))
+}
+
+// routineDoneOn reports whether every due step of a routine was done on day.
+// counted is false when no step was due, so the day neither counts nor breaks.
+func routineDoneOn(data *AppData, r Routine, day string) (done, counted bool) {
+	done = true
+	for _, step := range r.Steps {
+		daily, ok := routineDaily(data, step)
+		if !ok || daily.Kind == kindAvoid || excusedOn(*daily, day) || !dueOn(*daily, day) {
+			continue
+		}
+		counted = true
+		if !doneOn(*daily, day) {
+			done = false
+		}
+	}
+	return done && counted, counted
+}
+
+// routineStreak counts consecutive days the whole routine was completed.
+// Today only counts once it's complete.
+func routineStreak(data *AppData, r Routine, today string) int {
+	streak := 0
+	for i := 0; i <= 366; i++ {
+		day := addDays(today, -i)
+		done, counted := routineDoneOn(data, r, day)
+		switch {
+		case !counted:
+			continue
+		case done:
+			streak++
+		case day == today:
+			continue
+		default:
+			return streak
+		}
+	}
+	return streak
+}
+
+// updateRoutineStreak refreshes the streak of the routine a completed daily
+// belongs to and reports whether that completion finished the routine
+func updateRoutineStreak(data *AppData, daily Daily) (*Routine, bool) {
+	r, _ := routineOf(data, daily.ID)
+	if r == nil {
+		return nil, false
+	}
+	today := logicalDay(time.Now())
+	r.CurrentStreak = routineStreak(data, *r, today)
+	r.BestStreak = max(r.BestStreak, r.CurrentStreak)
+	done, _ := routineDoneOn(data, *r, today)
+	return r, done
+}
+
+// parseRoutineSteps reads "Stretch:5, Meditate:10, Journal" into steps,
+// matching dailies by ID or name. The number after ":" is a step timer in
+// minutes. Each daily can be a step only once.
+func parseRoutineSteps(data AppData, value string) ([]RoutineStep, error) {
+	var steps []RoutineStep
+	seen := map[int]bool{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		step := RoutineStep{}
+		if name, minutes, found := strings.Cut(item, ":"); found {
+			n, err := strconv.Atoi(strings.TrimSpace(minutes))
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid step minutes in %q", item)
+			}
+			item, step.Minutes = strings.TrimSpace(name), n
+		}
+		for _, daily := range data.Dailies {
+			if matchesDaily(daily, item) {
+				step.DailyID = daily.ID
+			}
+		}
+		if step.DailyID == 0 {
+			return nil, fmt.Errorf("no daily matched %q", item)
+		}
+		if seen[step.DailyID] {
+			return nil, fmt.Errorf("%q is already a step of this routine", item)
+		}
+		seen[step.DailyID] = true
+		steps = append(steps, step)
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("a routine needs at least one daily")
+	}
+	return steps, nil
+}
+
+// setRoutine creates or replaces a routine by name. A daily can only be in
+// one routine, so steps are taken out of any other routine.
+func setRoutine(data *AppData, name, window string, steps []RoutineStep) {
+	for i := range data.Routines {
+		if strings.EqualFold(data.Routines[i].Name, name) {
+			continue
+		}
+		kept := data.Routines[i].Steps[:0]
+		for _, existing := range data.Routines[i].Steps {
+			taken := false
+			for _, step := range steps {
+				taken = taken || step.DailyID == existing.DailyID
+			}
+			if !taken {
+				kept = append(kept, existing)
+			}
+		}
+		data.Routines[i].Steps = kept
+	}
+
+	for i := range data.Routines {
+		if strings.EqualFold(data.Routines[i].Name, name) {
+			data.Routines[i].Steps = steps
+			data.Routines[i].Window = window
+			return
+		}
+	}
+	data.Routines = append(data.Routines, Routine{
+		ID:     nextID(data.Routines, func(r Routine) int { return r.ID }),
+		Name:   name,
+		Window: window,
+		Steps:  steps,
+	})
+}
+
+// groupRoutines reorders the first shown dailies so each routine's steps sit
+// together in step order, ahead of dailies outside any routine. Steps of a
+// collapsed routine after the first are moved past the shown rows. Each
+// daily is placed once, even if a hand-edited config repeats it. Returns the
+// new number of shown rows.
+func (m *model) groupRoutines(shown int) int {
+	rows := make([]Daily, 0, shown)
+	var hidden []Daily
+	placed := map[int]bool{}
+	for _, r := range m.data.Routines {
+		first := true
+		for _, step := range r.Steps {
+			for _, daily := range m.data.Dailies[:shown] {
+				if daily.ID != step.DailyID || placed[daily.ID] {
+					continue
+				}
+				if m.collapsed[r.ID] && !first {
+					hidden = append(hidden, daily)
+				} else {
+					rows = append(rows, daily)
+				}
+				placed[daily.ID] = true
+				first = false
+			}
+		}
+	}
+	for _, daily := range m.data.Dailies[:shown] {
+		if !placed[daily.ID] {
+			rows = append(rows, daily)
+		}
+	}
+	reordered := append(append(rows, hidden...), m.data.Dailies[shown:]...)
+	copy(m.data.Dailies, reordered)
+	return len(rows)
+}
+
+// collapsedRoutine returns the routine a Dailies row stands for when the row
+// is a collapsed routine rather than a single daily
+func (m *model) collapsedRoutine(cursor int) *Routine {
+	if !m.groupByRoutine || m.showArchived {
+		return nil
+	}
+	if r, _ := routineOf(&m.data, m.data.Dailies[cursor].ID); r != nil && m.collapsed[r.ID] {
+		return r
+	}
+	return nil
+}
+
+// selectedSingleDaily is selectedDaily for actions on one daily. A collapsed
+// routine row isn't one, so it warns instead.
+func (m *model) selectedSingleDaily() (int, bool) {
+	cursor, ok := m.selectedDaily()
+	if !ok {
+		return cursor, false
+	}
+	if r := m.collapsedRoutine(cursor); r != nil {
+		m.pushToast(fmt.Sprintf("⚠️ %s is collapsed, o expands it to pick a daily", r.Name), toastWarning)
+		return cursor, false
+	}
+	return cursor, true
+}
+
+// routineRowTask is the Task column for a daily when grouping by routine
+func (m *model) routineRowTask(daily Daily, task string) (string, *Routine) {
+	r, step := routineOf(&m.data, daily.ID)
+	if r == nil {
+		return task, nil
+	}
+	if m.collapsed[r.ID] {
+		done, total := routineProgress(&m.data, *r, logicalDay(time.Now()))
+		return fmt.Sprintf("▸ %s (%d/%d)", r.Name, done, total), r
+	}
+	if step == 0 {
+		return fmt.Sprintf("▾ %s: 1. %s", r.Name, task), nil
+	}
+	return fmt.Sprintf("    %d. %s", step+1, task), nil
+}
+
+// toggleRoutineCollapse collapses or expands the routine of the selected daily
+func (m *model) toggleRoutineCollapse() {
+	cursor, ok := m.selectedDaily()
+	if !m.groupByRoutine || !ok {
+		return
+	}
+	r, _ := routineOf(&m.data, m.data.Dailies[cursor].ID)
+	if r == nil {
+		return
+	}
+	m.collapsed[r.ID] = !m.collapsed[r.ID]
+	m.tables[0].SetRows(m.dailyRows())
+}
+
+// toggleGroupByRoutine switches the Dailies table between sorted and grouped
+func (m *model) toggleGroupByRoutine() {
+	if len(m.data.Routines) == 0 {
+		m.pushToast("⚠️ No routines yet, create one with `lif routine set`", toastWarning)
+		return
+	}
+	m.groupByRoutine = !m.groupByRoutine
+	m.tables[0].SetRows(m.dailyRows())
+	if m.groupByRoutine {
+		m.pushToast("🧭 Grouped by routine (o collapses/expands)", toastInfo)
+	} else {
+		m.pushToast("Routine grouping off", toastInfo)
+	}
+}
+
+// startRoutine enters run mode on the first unfinished step
+func (m *model) startRoutine(r *Routine) {
+	m.showRoutine = true
+	m.routineID = r.ID
+	m.routineStep = 0
+	m.nextRoutineStep(0)
+}
+
+// runSelectedRoutine runs the routine of the selected daily, or on Home the
+// routine whose window is open (else the first unfinished one)
+func (m *model) runSelectedRoutine() {
+	if m.activeTab == 2 {
+		cursor, ok := m.selectedDaily()
+		if !ok {
+			return
+		}
+		if r, _ := routineOf(&m.data, m.data.Dailies[cursor].ID); r != nil {
+			m.startRoutine(r)
+			return
+		}
+		m.pushToast(fmt.Sprintf("⚠️ %s is not in a routine", m.data.Dailies[cursor].Task), toastWarning)
+		return
+	}
+
+	today := logicalDay(time.Now())
+	var pick *Routine
+	for i := range m.data.Routines {
+		r := &m.data.Routines[i]
+		if done, total := routineProgress(&m.data, *r, today); done == total {
+			continue
+		}
+		if r.inWindow(time.Now()) {
+			pick = r
+			break
+		}
+		if pick == nil {
+			pick = r
+		}
+	}
+	if pick == nil {
+		m.pushToast("✅ No unfinished routines today", toastInfo)
+		return
+	}
+	m.startRoutine(pick)
+}
+
+// activeRoutine returns the routine in run mode
+func (m *model) activeRoutine() *Routine {
+	for i := range m.data.Routines {
+		if m.data.Routines[i].ID == m.routineID {
+			return &m.data.Routines[i]
+		}
+	}
+	return nil
+}
+
+// nextRoutineStep moves to the first unfinished step at or after from and
+// restarts the step timer
+func (m *model) nextRoutineStep(from int) {
+	r := m.activeRoutine()
+	if r == nil {
+		return
+	}
+	today := logicalDay(time.Now())
+	m.routineStep = from
+	for m.routineStep < len(r.Steps) {
+		daily, ok := routineDaily(&m.data, r.Steps[m.routineStep])
+		if ok && !daily.Paused && daily.Kind != kindAvoid && !doneOn(*daily, today) {
+			break
+		}
+		m.routineStep++
+	}
+	m.stepStarted = time.Now()
+	m.stepNotified = false
+}
+
+// completeRoutineStep completes the current step and moves on
+func (m *model) completeRoutineStep() {
+	r := m.activeRoutine()
+	if r == nil || m.routineStep >= len(r.Steps) {
+		return
+	}
+	daily, ok := routineDaily(&m.data, r.Steps[m.routineStep])
+	if !ok {
+		m.nextRoutineStep(m.routineStep + 1)
+		return
+	}
+	if daily.Target > 0 {
+		setAmount(&m.data, daily, daily.Target)
+	} else {
+		completeDaily(&m.data, daily)
+	}
+	m.nextRoutineStep(m.routineStep + 1)
+	if m.routineStep >= len(r.Steps) {
+		m.pushToast(fmt.Sprintf("🧭 %s complete! %d day routine streak 🔥", r.Name, r.CurrentStreak), toastSuccess)
+	} else {
+		m.pushToast(fmt.Sprintf("✅ %s", daily.Task), toastSuccess)
+	}
+	m.tables[0].SetRows(m.dailyRows())
+	saveData(m.data)
+	m.achievementEvent(eventCompletion)
+}
+
+// tickRoutine announces when the current step's timer runs out
+func (m *model) tickRoutine(now time.Time) {
+	r := m.activeRoutine()
+	if !m.showRoutine || r == nil || m.routineStep >= len(r.Steps) || m.stepNotified {
+		return
+	}
+	step := r.Steps[m.routineStep]
+	if step.Minutes == 0 || now.Sub(m.stepStarted) < time.Duration(step.Minutes)*time.Minute {
+		return
+	}
+	m.stepNotified = true
+	name := ""
+	if daily, ok := routineDaily(&m.data, step); ok {
+		name = daily.Task
+	}
+	m.notify(Notification{
+		Title:      r.Name,
+		Message:    fmt.Sprintf("%s: %dm up, on to the next step", name, step.Minutes),
+		Level:      levelNormal,
+		SourceType: "daily",
+		SourceID:   step.DailyID,
+	}, false)
+	m.pushToast(fmt.Sprintf("⏱️ %s: time's up", name), toastInfo)
+}
+
+func (m model) handleRoutineKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	r := m.activeRoutine()
+	if r == nil {
+		m.showRoutine = false
+		return m, nil
+	}
+	switch msg.String() {
+	case "esc", "q":
+		m.showRoutine = false
+	case " ", "enter":
+		m.completeRoutineStep()
+	case "n", "right", "l":
+		// Skip ahead without completing
+		if m.routineStep < len(r.Steps) {
+			m.routineStep++
+			m.stepStarted = time.Now()
+			m.stepNotified = false
+		}
+	case "p", "left", "h":
+		if m.routineStep > 0 {
+			m.routineStep--
+			m.stepStarted = time.Now()
+			m.stepNotified = false
+		}
+	}
+	return m, nil
+}
+
+func (m model) routineView() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("105"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+
+	r := m.activeRoutine()
+	if r == nil {
+		return ""
+	}
+	today := logicalDay(time.Now())
+	done, total := routineProgress(&m.data, *r, today)
+	header := fmt.Sprintf("🧭 %s  %d/%d", r.Name, done, total)
+	if r.Window != "" {
+		header += "  " + r.Window
+	}
+	lines := []string{
+		titleStyle.Render(header),
+		dimStyle.Render(fmt.Sprintf("   Routine streak %d, best %d", r.CurrentStreak, r.BestStreak)),
+		"",
+	}
+
+	for i, step := range r.Steps {
+		daily, ok := routineDaily(&m.data, step)
+		if !ok {
+			continue
+		}
+		mark := "○"
+		switch {
+		case doneOn(*daily, today):
+			mark = statusDoneStyle.Render("✓")
+		case daily.Paused:
+			mark = "⏸"
+		}
+		line := fmt.Sprintf("%s %d. %s", mark, i+1, daily.Task)
+		if step.Minutes > 0 {
+			line += dimStyle.Render(fmt.Sprintf("  %dm", step.Minutes))
+		}
+		if i == m.routineStep {
+			line = selectedStyle.Render(fmt.Sprintf("▶ %d. %s", i+1, daily.Task))
+			if step.Minutes > 0 {
+				left := time.Duration(step.Minutes)*time.Minute - time.Since(m.stepStarted)
+				if left > 0 {
+					line += "  ⏱️ " + left.Truncate(time.Second).String() + " left"
+				} else {
+					line += "  " + statusOverdueStyle.Render("⏱️ time's up")
+				}
+			}
+		}
+		lines = append(lines, " "+line)
+	}
+	if m.routineStep >= len(r.Steps) {
+		lines = append(lines, "", statusDoneStyle.Render(" 🎉 All steps done"))
+	}
+
+	return lipgloss.NewStyle().Padding(0, 1).Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Transaction kinds stored in Transaction.Kind
+const (
+	txPurchase = "purchase"
+	txPenalty  = "penalty" // Points lost when health runs out
+)
+
+// "Episode of a show - 50 pts", with -, —, : or = before the cost
+var rewardPattern = regexp.MustCompile(`^(.+?)\s*[-—–:=]\s*(\d+)\s*(?:pts?|points?)?$`)
+
+// parseReward reads a reward and its cost from one line
+func parseReward(value string) (Reward, bool) {
+	match := rewardPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return Reward{}, false
+	}
+	cost, err := strconv.Atoi(match[2])
+	if err != nil || cost <= 0 {
+		return Reward{}, false
+	}
+	return Reward{Name: normalizeText(match[1]), Cost: cost}, true
+}
+
+// pointsBalance is every XP point earned plus the transactions still in
+// effect. Spending points never lowers the level, which counts lifetime XP.
+func pointsBalance(data AppData) int {
+	balance := totalXP(data.XPLedger, "")
+	for _, tx := range data.Transactions {
+		if !tx.Undone {
+			balance += tx.Amount
+		}
+	}
+	return balance
+}
+
+// checkSpendable refuses a change that takes back lost XP when those points
+// were already spent: undoing completions may not push the balance below zero.
+// Callers check before changing anything.
+func checkSpendable(data AppData, lost int) error {
+	if lost > 0 && pointsBalance(data)-lost < 0 {
+		return fmt.Errorf("that takes back %d XP already spent in the shop, refund a purchase first", lost)
+	}
+	return nil
+}
+
+// undoLoss is the XP undoTaskStreak takes back from a daily that is DONE
+func undoLoss(data AppData, daily Daily) int {
+	if daily.Status != "DONE" {
+		return 0
+	}
+	day := completedDay(daily, logicalDay(time.Now()))
+	for _, entry := range data.XPLedger {
+		if entry.Source == linkDaily && entry.SourceID == daily.ID && entry.Day == day {
+			return entry.Points
+		}
+	}
+	return 0
+}
+
+// amountLoss is the XP setAmount takes back when it logs amount on a daily
+func amountLoss(data AppData, daily Daily, amount float64) int {
+	if amount >= daily.Target {
+		return 0
+	}
+	return undoLoss(data, daily)
+}
+
+// backfillLoss is the XP backfillDay takes back when it marks day done or
+// not done
+func backfillLoss(data AppData, daily Daily, day string, done bool) int {
+	switch {
+	case daily.Kind == kindAvoid:
+		return 0
+	case day == logicalDay(time.Now()) && done:
+		return 0
+	case day == logicalDay(time.Now()):
+		return undoLoss(data, daily)
+	}
+	changed := daily
+	changed.History = maps.Clone(daily.History)
+	if changed.History == nil {
+		changed.History = map[string]DayRecord{}
+	}
+	if done {
+		changed.History[day] = DayRecord{Status: dayDone}
+	} else if changed.History[day].Status == dayDone {
+		markDay(&changed, day, dayPartial)
+	}
+	return recomputeLoss(data, changed)
+}
+
+// recomputeLoss is the XP recomputeXP takes back, with changed standing in
+// for the dailies of the same ID
+func recomputeLoss(data AppData, changed ...Daily) int {
+	dailies := dailiesByID(data.Dailies)
+	for _, daily := range changed {
+		dailies[daily.ID] = daily
+	}
+	lost := 0
+	for _, entry := range data.XPLedger {
+		after, ok := recomputedEntry(entry, dailies)
+		if !ok {
+			after.Points = 0
+		}
+		lost += entry.Points - after.Points
+	}
+	return lost
+}
+
+// addReward adds a reward to the shop, or updates the cost of one with the
+// same name
+func addReward(data *AppData, reward Reward) {
+	for i := range data.Rewards {
+		if strings.EqualFold(data.Rewards[i].Name, reward.Name) {
+			data.Rewards[i].Cost = reward.Cost
+			return
+		}
+	}
+	reward.ID = nextID(data.Rewards, func(r Reward) int { return r.ID })
+	data.Rewards = append(data.Rewards, reward)
+}
+
+// buyReward spends points on a reward
+func buyReward(data *AppData, reward Reward) error {
+	if balance := pointsBalance(*data); balance < reward.Cost {
+		return fmt.Errorf("%s costs %d pts, you have %d", reward.Name, reward.Cost, balance)
+	}
+	data.Transactions = append(data.Transactions, Transaction{
+		ID:       nextID(data.Transactions, func(t Transaction) int { return t.ID }),
+		Time:     time.Now(),
+		Kind:     txPurchase,
+		Name:     reward.Name,
+		RewardID: reward.ID,
+		Amount:   -reward.Cost,
+	})
+	return nil
+}
+
+// undoPurchase refunds the most recent purchase still in effect. It stays in
+// the history, marked undone.
+func undoPurchase(data *AppData) (Transaction, error) {
+	for i := len(data.Transactions) - 1; i >= 0; i-- {
+		if tx := &data.Transactions[i]; tx.Kind == txPurchase && !tx.Undone {
+			tx.Undone = true
+			return *tx, nil
+		}
+	}
+	return Transaction{}, fmt.Errorf("no purchases to undo")
+}
+
+// openShop shows the rewards shop
+func (m *model) openShop() {
+	m.showShop = true
+	m.shopAdding = false
+	m.shopCursor = min(m.shopCursor, max(len(m.data.Rewards)-1, 0))
+}
+
+func (m model) handleShopKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.shopAdding {
+		switch msg.String() {
+		case "esc":
+			m.shopAdding = false
+		case "enter":
+			reward, ok := parseReward(m.shopInput.Value())
+			if !ok {
+				m.pushToast(fmt.Sprintf("⚠️ Couldn't read %q, try \"Episode of a show - 50\"", m.shopInput.Value()), toastWarning)
+				return m, nil
+			}
+			m.shopAdding = false
+			addReward(&m.data, reward)
+			saveData(m.data)
+			m.pushToast(fmt.Sprintf("🛍️ %s added for %d pts", reward.Name, reward.Cost), toastSuccess)
+		default:
+			var cmd tea.Cmd
+			m.shopInput, cmd = m.shopInput.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "$", "esc", "q":
+		m.showShop = false
+	case "up", "k":
+		if m.shopCursor > 0 {
+			m.shopCursor--
+		}
+	case "down", "j":
+		if m.shopCursor < len(m.data.Rewards)-1 {
+			m.shopCursor++
+		}
+	case "n", "a":
+		m.shopAdding = true
+		m.shopInput = textinput.New()
+		m.shopInput.Placeholder = "Episode of a show - 50"
+		m.shopInput.CharLimit = 80
+		m.shopInput.Focus()
+	case "d", "delete":
+		if m.shopCursor < len(m.data.Rewards) {
+			name := m.data.Rewards[m.shopCursor].Name
+			m.data.Rewards = append(m.data.Rewards[:m.shopCursor], m.data.Rewards[m.shopCursor+1:]...)
+			m.shopCursor = min(m.shopCursor, max(len(m.data.Rewards)-1, 0))
+			saveData(m.data)
+			m.pushToast(fmt.Sprintf("Removed %s from the shop", name), toastInfo)
+		}
+	case " ", "enter":
+		if m.shopCursor >= len(m.data.Rewards) {
+			return m, nil
+		}
+		reward := m.data.Rewards[m.shopCursor]
+		if err := buyReward(&m.data, reward); err != nil {
+			m.pushToast("⚠️ "+err.Error(), toastWarning)
+			return m, nil
+		}
+		saveData(m.data)
+		m.pushToast(fmt.Sprintf("🎁 Bought %s for %d pts, %d left (u to undo)", reward.Name, reward.Cost, pointsBalance(m.data)), toastSuccess)
+	case "u":
+		tx, err := undoPurchase(&m.data)
+		if err != nil {
+			m.pushToast("⚠️ "+err.Error(), toastWarning)
+			return m, nil
+		}
+		saveData(m.data)
+		m.pushToast(fmt.Sprintf("↩️ Refunded %s, %d pts back", tx.Name, -tx.Amount), toastInfo)
+	}
+	return m, nil
+}
+
+func (m model) shopView() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("105"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+
+	balance := pointsBalance(m.data)
+	lines := []string{titleStyle.Render(fmt.Sprintf("🛍️ Rewards shop  💰 %d pts", balance)), ""}
+	if len(m.data.Rewards) == 0 {
+		lines = append(lines, dimStyle.Render(" No rewards yet, n to add one"))
+	}
+	for i, reward := range m.data.Rewards {
+		line := fmt.Sprintf("%-32s %5d pts", reward.Name, reward.Cost)
+		switch {
+		case i == m.shopCursor:
+			line = selectedStyle.Render(line)
+		case reward.Cost > balance:
+			line = dimStyle.Render(line)
+		}
+		lines = append(lines, " "+line)
+	}
+	if m.shopAdding {
+		lines = append(lines, "", " New reward (name - cost): "+m.shopInput.View())
+	}
+
+	// Latest transactions, newest first
+	lines = append(lines, "", titleStyle.Render("Purchase history"))
+	shown := 0
+	for i := len(m.data.Transactions) - 1; i >= 0 && shown < 10; i, shown = i-1, shown+1 {
+		tx := m.data.Transactions[i]
+		line := fmt.Sprintf(" %s  %+5d  %s", tx.Time.Format("Jan 2 15:04"), tx.Amount, tx.Name)
+		if tx.Kind != txPurchase {
+			line += " (" + tx.Kind + ")"
+		}
+		if tx.Undone {
+			line = dimStyle.Render(line + " (undone)")
+		}
+		lines = append(lines, line)
+	}
+	if len(m.data.Transactions) == 0 {
+		lines = append(lines, dimStyle.Render(" Nothing bought yet"))
+	}
+
+	return lipgloss.NewStyle().Padding(0, 1).Render(strings.Join(lines, "\n"))
+}
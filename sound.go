@@ -0,0 +1,171 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+)
+
+// Default notification sounds ship inside the binary and are extracted to the
+// user cache dir on first use, since players need a real file path
+//
+//go:embed assets/notification.mp3 assets/notification.wav
+var embeddedSounds embed.FS
+
+// soundPlayer describes an external audio player and how to invoke it
+type soundPlayer struct {
+	Name    string
+	Path    string
+	WavOnly bool                                   // Player can't decode mp3
+	args    func(file string, volume int) []string // volume is 1-150
+}
+
+// candidatePlayers lists audio players in order of preference for this platform
+func candidatePlayers() []soundPlayer {
+	switch {
+	case runtime.GOOS == "darwin":
+		return []soundPlayer{
+			{Name: "afplay", args: func(file string, volume int) []string {
+				return []string{"-v", strconv.FormatFloat(float64(volume)/100, 'f', 2, 64), file}
+			}},
+		}
+	case runtime.GOOS == "windows":
+		return []soundPlayer{
+			{Name: "powershell", WavOnly: true, args: func(file string, volume int) []string {
+				return []string{"-NoProfile", "-Command", `(New-Object Media.SoundPlayer $env:LIF_SOUND).PlaySync()`}
+			}},
+		}
+	}
+
+	// Linux and WSL
+	return []soundPlayer{
+		{Name: "mpv", args: func(file string, volume int) []string {
+			return []string{"--no-video", "--really-quiet", "--audio-buffer=1.0", fmt.Sprintf("--volume=%d", volume), file}
+		}},
+		{Name: "ffplay", args: func(file string, volume int) []string {
+			return []string{"-nodisp", "-autoexit", "-v", "quiet", "-volume", strconv.Itoa(min(volume, 100)), file}
+		}},
+		{Name: "paplay", WavOnly: true, args: func(file string, volume int) []string {
+			return []string{fmt.Sprintf("--volume=%d", volume*65536/100), file}
+		}},
+		{Name: "mplayer", args: func(file string, volume int) []string {
+			return []string{"-really-quiet", "-softvol", "-volume", strconv.Itoa(min(volume, 100)), file}
+		}},
+		{Name: "cvlc", args: func(file string, volume int) []string {
+			return []string{"--play-and-exit", "--gain", strconv.FormatFloat(float64(volume)/100, 'f', 2, 64), file}
+		}},
+		{Name: "aplay", WavOnly: true, args: func(file string, volume int) []string {
+			return []string{"-q", file}
+		}},
+	}
+}
+
+// choosePlayer returns the first installed audio player
+func choosePlayer() (soundPlayer, bool) {
+	for _, player := range candidatePlayers() {
+		if path, err := exec.LookPath(player.Name); err == nil {
+			player.Path = path
+			return player, true
+		}
+	}
+	return soundPlayer{}, false
+}
+
+// defaultSoundFile extracts the embedded sound to the cache dir and returns
+// its path. The wav copy is used for players that can't decode mp3.
+func defaultSoundFile(wav bool) (string, error) {
+	name := "notification.mp3"
+	if wav {
+		name = "notification.wav"
+	}
+
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(cacheDir, "lif", "sounds", name)
+
+	contents, err := embeddedSounds.ReadFile("assets/" + name)
+	if err != nil {
+		return "", err
+	}
+	// Re-extract if missing or left over from a different build
+	if info, err := os.Stat(path); err == nil && info.Size() == int64(len(contents)) {
+		return path, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, contents, 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// resolveSound picks the file and volume to play: per-reminder values win over
+// the global default, and a missing custom file falls back to the embedded one
+func resolveSound(cfg SoundConfig, file string, volume int, player soundPlayer) (string, int, error) {
+	if file == "" {
+		file = cfg.File
+	}
+	if volume <= 0 {
+		volume = cfg.Volume
+	}
+	if volume <= 0 {
+		volume = 100
+	}
+
+	if file != "" {
+		if _, err := os.Stat(file); err == nil {
+			return file, volume, nil
+		}
+	}
+	path, err := defaultSoundFile(player.WavOnly)
+	return path, volume, err
+}
+
+// startSound launches the player without waiting for it to finish
+func startSound(cfg SoundConfig, file string, volume int, loud bool) (*exec.Cmd, soundPlayer, string, error) {
+	player, ok := choosePlayer()
+	if !ok {
+		return nil, soundPlayer{}, "", fmt.Errorf("no audio player found")
+	}
+	path, volume, err := resolveSound(cfg, file, volume, player)
+	if err != nil {
+		return nil, player, "", err
+	}
+	if loud {
+		// Escalated alarms play half again as loud
+		volume = min(volume*3/2, 150)
+	}
+
+	cmd := exec.Command(player.Path, player.args(path, volume)...)
+	cmd.Env = append(os.Environ(), "LIF_SOUND="+path)
+	if err := cmd.Start(); err != nil {
+		return nil, player, path, err
+	}
+	return cmd, player, path, nil
+}
+
+func playNotificationSound(cfg SoundConfig, file string, volume int, loud bool) {
+	cmd, _, _, err := startSound(cfg, file, volume, loud)
+	if err != nil {
+		// No player or sound available, fall back to a beep
+		if isWSL() {
+			go exec.Command("powershell.exe", "-Command", "[console]::beep(800,200)").Run()
+		} else {
+			ringBell()
+		}
+		return
+	}
+	go cmd.Wait()
+}
+
+// ringBell writes a BEL to the controlling terminal
+func ringBell() {
+	os.Stdout.WriteString("\a")
+}
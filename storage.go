@@ -5,8 +5,37 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"time"
 )
 
+// configModTime is the config file's mtime as of our last load or save. The
+// TUI compares against it to pick up changes made by CLI commands.
+var configModTime time.Time
+
+func configPath() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		log.Fatal(err)
+	}
+	return filepath.Join(configDir, "lif", "config.json")
+}
+
+func recordConfigModTime(path string) {
+	if info, err := os.Stat(path); err == nil {
+		configModTime = info.ModTime()
+	}
+}
+
+// dataChangedOnDisk reports whether another process has written the config
+// since we last loaded or saved it
+func dataChangedOnDisk() bool {
+	info, err := os.Stat(configPath())
+	if err != nil {
+		return false
+	}
+	return !info.ModTime().Equal(configModTime)
+}
+
 func initializeReference() []ReferenceItem {
 	return []ReferenceItem{
 		// Git commands
@@ -77,12 +106,7 @@ func initializeReference() []ReferenceItem {
 }
 
 func loadData() AppData {
-	configDir, err := os.UserConfigDir()
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	configPath := filepath.Join(configDir, "lif", "config.json")
+	configPath := configPath()
 
 	// Create directory if it doesn't exist
 	os.MkdirAll(filepath.Dir(configPath), 0755)
@@ -92,6 +116,13 @@ func loadData() AppData {
 		RollingTodos: []RollingTodo{},
 		Reminders:    []Reminder{},
 		Reference:    initializeReference(),
+		Settings: Settings{
+			DayStartHour: defaultDayStartHour,
+			Freezes:      FreezeConfig{Max: 2, EarnEvery: 7},
+			BackfillDays: defaultBackfillDays,
+			Health:       defaultHealth(),
+		},
+		Health: Health{HP: defaultMaxHP},
 	}
 
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
@@ -104,12 +135,20 @@ func loadData() AppData {
 	if err != nil {
 		log.Fatal(err)
 	}
+	recordConfigModTime(configPath)
 
 	if err := json.Unmarshal(file, &data); err != nil {
 		log.Printf("Warning: failed to parse config: %v. Using defaults.", err)
 		return data
 	}
 
+	applyDaySettings(data.Settings)
+
+	// Dailies saved before per-day history existed
+	for i := range data.Dailies {
+		migrateHistory(&data.Dailies[i])
+	}
+
 	// Initialize reminders that need parsing
 	for i := range data.Reminders {
 		reminder := &data.Reminders[i]
@@ -130,12 +169,7 @@ func loadData() AppData {
 }
 
 func saveData(data AppData) {
-	configDir, err := os.UserConfigDir()
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	configPath := filepath.Join(configDir, "lif", "config.json")
+	configPath := configPath()
 
 	file, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
@@ -146,4 +180,5 @@ func saveData(data AppData) {
 	if err != nil {
 		log.Fatal(err)
 	}
+	recordConfigModTime(configPath)
 }
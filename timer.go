@@ -0,0 +1,302 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Timer modes stored in Reminder.Mode; empty means a countdown or alarm
+const (
+	modeStopwatch = "stopwatch"
+	modePomodoro  = "pomodoro"
+)
+
+// Pomodoro phases stored in Reminder.Phase
+const (
+	phaseWork      = "work"
+	phaseBreak     = "break"
+	phaseLongBreak = "long break"
+)
+
+// pomodoroConfig holds interval lengths parsed from "pomodoro 25/5/15/4"
+type pomodoroConfig struct {
+	Work      time.Duration
+	Break     time.Duration
+	LongBreak time.Duration
+	Every     int // Long break after this many work sessions
+}
+
+var defaultPomodoro = pomodoroConfig{
+	Work:      25 * time.Minute,
+	Break:     5 * time.Minute,
+	LongBreak: 15 * time.Minute,
+	Every:     4,
+}
+
+// parseTimerMode recognises "stopwatch" and "pomodoro [work/break/long/every]"
+// in the Alarm/Countdown field. Lengths are minutes or interval shorthand.
+func parseTimerMode(value string) (string, pomodoroConfig, bool) {
+	fields := strings.Fields(normalizeText(value))
+	if len(fields) == 0 {
+		return "", pomodoroConfig{}, false
+	}
+	switch fields[0] {
+	case modeStopwatch:
+		if len(fields) == 1 {
+			return modeStopwatch, pomodoroConfig{}, true
+		}
+	case modePomodoro:
+		cfg := defaultPomodoro
+		if len(fields) == 1 {
+			return modePomodoro, cfg, true
+		}
+		parts := strings.Split(fields[1], "/")
+		lengths := []*time.Duration{&cfg.Work, &cfg.Break, &cfg.LongBreak}
+		for i, part := range parts {
+			if i == 3 {
+				every, err := strconv.Atoi(part)
+				if err != nil || every <= 0 {
+					return "", pomodoroConfig{}, false
+				}
+				cfg.Every = every
+				continue
+			}
+			if i > 3 {
+				return "", pomodoroConfig{}, false
+			}
+			d, ok := parseTimerLength(part)
+			if !ok {
+				return "", pomodoroConfig{}, false
+			}
+			*lengths[i] = d
+		}
+		return modePomodoro, cfg, true
+	}
+	return "", pomodoroConfig{}, false
+}
+
+// parseTimerLength reads a bare number as minutes, otherwise interval shorthand
+func parseTimerLength(value string) (time.Duration, bool) {
+	if minutes, err := strconv.Atoi(value); err == nil && minutes > 0 {
+		return time.Duration(minutes) * time.Minute, true
+	}
+	d, ok := parseInterval(value)
+	return d, ok && d > 0
+}
+
+// phaseLength returns how long a pomodoro phase lasts
+func (c pomodoroConfig) phaseLength(phase string) time.Duration {
+	switch phase {
+	case phaseBreak:
+		return c.Break
+	case phaseLongBreak:
+		return c.LongBreak
+	default:
+		return c.Work
+	}
+}
+
+// stopwatchElapsed is the total running time, including the current segment
+func stopwatchElapsed(r Reminder, now time.Time) time.Duration {
+	if r.Status == "active" && !r.StartedAt.IsZero() {
+		return r.Elapsed + now.Sub(r.StartedAt)
+	}
+	return r.Elapsed
+}
+
+// startTimer (re)starts a stopwatch or pomodoro from zero
+func startTimer(r *Reminder, now time.Time) {
+	mode, cfg, _ := parseTimerMode(r.AlarmOrCountdown)
+	r.Mode = mode
+	r.Status = "active"
+	r.PausedRemaining = 0
+	r.Elapsed = 0
+	r.Laps = nil
+	r.StartedAt = now
+	r.Cycle = 0
+	r.Notified = false
+	r.Acknowledged = false
+	r.NotifyCount = 0
+	r.LastNotified = time.Time{}
+	r.TargetTime = time.Time{}
+	r.IsCountdown = false
+	r.Phase = ""
+	if mode == modePomodoro {
+		r.Phase = phaseWork
+		r.TargetTime = now.Add(cfg.Work)
+		r.IsCountdown = true
+	}
+}
+
+// toggleTimer applies start/pause/reset to a stopwatch or pomodoro and
+// returns the status message to show
+func toggleTimer(r *Reminder, action string, now time.Time) (string, toastSeverity) {
+	switch action {
+	case "start":
+		switch r.Status {
+		case "paused":
+			r.StartedAt = now
+			if r.Mode == modePomodoro && r.PausedRemaining > 0 {
+				r.TargetTime = now.Add(r.PausedRemaining)
+				r.PausedRemaining = 0
+			}
+			r.Status = "active"
+			return fmt.Sprintf("▶️ Resumed: %s", r.Reminder), toastSuccess
+		case "active":
+			return fmt.Sprintf("⚠️ %s is already active", r.Reminder), toastWarning
+		default:
+			startTimer(r, now)
+			return fmt.Sprintf("▶️ Started: %s", r.Reminder), toastSuccess
+		}
+	case "pause":
+		if r.Status != "active" {
+			return fmt.Sprintf("⚠️ %s is not active", r.Reminder), toastWarning
+		}
+		r.Elapsed = stopwatchElapsed(*r, now)
+		r.StartedAt = time.Time{}
+		if r.Mode == modePomodoro {
+			r.PausedRemaining = max(r.TargetTime.Sub(now), 0)
+		}
+		r.Status = "paused"
+		return fmt.Sprintf("⏸️ Paused: %s", r.Reminder), toastWarning
+	case "reset":
+		startTimer(r, now)
+		return fmt.Sprintf("🔄 Reset: %s", r.Reminder), toastSuccess
+	}
+	return "", toastInfo
+}
+
+// tickTimer advances a running timer: stopwatches notify at each Repeat every
+// milestone, pomodoros move to the next phase when the current one ends
+func (m *model) tickTimer(r *Reminder, now time.Time) {
+	if r.Status != "active" {
+		return
+	}
+
+	switch r.Mode {
+	case modeStopwatch:
+		every, ok := parseInterval(r.RepeatEvery)
+		if !ok || every <= 0 {
+			return
+		}
+		elapsed := stopwatchElapsed(*r, now)
+		if milestone := int(elapsed / every); milestone > r.NotifyCount {
+			r.NotifyCount = milestone
+			r.LastNotified = now
+			m.notify(Notification{
+				Title:      "Stopwatch",
+				Message:    fmt.Sprintf("%s: %s elapsed", r.Reminder, formatDuration(every*time.Duration(milestone))),
+				Level:      levelNormal,
+				Sound:      r.Sound,
+				Volume:     r.Volume,
+				SourceType: "timer",
+				SourceID:   r.ID,
+			}, r.Urgent)
+			saveData(m.data)
+		}
+	case modePomodoro:
+		if r.TargetTime.IsZero() || now.Before(r.TargetTime) {
+			return
+		}
+		_, cfg, _ := parseTimerMode(r.AlarmOrCountdown)
+		var message string
+		if r.Phase == phaseWork {
+			r.Cycle++
+			m.data.PomodoroLog = append(m.data.PomodoroLog, PomodoroRecord{
+				ReminderID: r.ID,
+				Task:       r.Reminder,
+				Completed:  now,
+				Minutes:    int(cfg.Work / time.Minute),
+			})
+			r.Phase = phaseBreak
+			if r.Cycle%cfg.Every == 0 {
+				r.Phase = phaseLongBreak
+			}
+			message = fmt.Sprintf("🍅 %s done (#%d), %s for %s", r.Reminder, r.Cycle, r.Phase, formatDuration(cfg.phaseLength(r.Phase)))
+		} else {
+			r.Phase = phaseWork
+			message = fmt.Sprintf("Break over, back to %s for %s", r.Reminder, formatDuration(cfg.Work))
+		}
+		r.TargetTime = now.Add(cfg.phaseLength(r.Phase))
+		r.LastNotified = now
+		m.notify(Notification{
+			Title:      "Pomodoro",
+			Message:    message,
+			Level:      levelNormal,
+			Sound:      r.Sound,
+			Volume:     r.Volume,
+			SourceType: "timer",
+			SourceID:   r.ID,
+		}, r.Urgent)
+		m.pushToast(message, toastInfo)
+		saveData(m.data)
+	}
+}
+
+// lapSelected records a lap on the selected stopwatch
+func (m *model) lapSelected() {
+	cursor := m.tables[2].Cursor()
+	if cursor >= len(m.data.Reminders) {
+		return
+	}
+
+	r := &m.data.Reminders[cursor]
+	if r.Mode != modeStopwatch || r.Status != "active" {
+		m.pushToast(fmt.Sprintf("⚠️ %s is not a running stopwatch", r.Reminder), toastWarning)
+		return
+	}
+
+	elapsed := stopwatchElapsed(*r, time.Now()).Truncate(time.Second)
+	split := elapsed
+	if len(r.Laps) > 0 {
+		split = elapsed - r.Laps[len(r.Laps)-1]
+	}
+	r.Laps = append(r.Laps, elapsed)
+	m.tables[2].SetRows(m.reminderRows())
+	saveData(m.data)
+	m.pushToast(fmt.Sprintf("⏱️ Lap %d: %s (+%s)", len(r.Laps), elapsed, split), toastInfo)
+}
+
+// timerDisplay is the Alarm/Countdown column text for a stopwatch or pomodoro
+func timerDisplay(r Reminder, now time.Time) string {
+	switch r.Mode {
+	case modeStopwatch:
+		text := fmt.Sprintf("⏱️ %s", stopwatchElapsed(r, now).Truncate(time.Second))
+		if len(r.Laps) > 0 {
+			text += fmt.Sprintf(" lap %d", len(r.Laps))
+		}
+		if r.Status == "paused" {
+			text += " (PAUSED)"
+		}
+		return text
+	case modePomodoro:
+		_, cfg, _ := parseTimerMode(r.AlarmOrCountdown)
+		remaining := max(time.Until(r.TargetTime), 0)
+		if r.Status == "paused" {
+			remaining = r.PausedRemaining
+		}
+		text := fmt.Sprintf("🍅 %s %d/%d %s", r.Phase, r.Cycle%cfg.Every+1, cfg.Every, remaining.Truncate(time.Second))
+		if r.Phase != phaseWork {
+			text = fmt.Sprintf("☕ %s %s", r.Phase, remaining.Truncate(time.Second))
+		}
+		if r.Status == "paused" {
+			text += " (PAUSED)"
+		}
+		return text
+	}
+	return r.AlarmOrCountdown
+}
+
+// pomodorosSince counts completed work sessions and their minutes since t
+func pomodorosSince(log []PomodoroRecord, t time.Time) (int, int) {
+	count, minutes := 0, 0
+	for _, record := range log {
+		if !record.Completed.Before(t) {
+			count++
+			minutes += record.Minutes
+		}
+	}
+	return count, minutes
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// toastSeverity sets a toast's color and how long it stays up
+type toastSeverity int
+
+const (
+	toastInfo toastSeverity = iota
+	toastSuccess
+	toastWarning
+	toastError
+)
+
+const (
+	maxVisibleToasts = 3   // Toasts stacked above the command row
+	maxToastLog      = 100 // Scrollback entries kept for the messages popup
+)
+
+func (s toastSeverity) color() string {
+	switch s {
+	case toastSuccess:
+		return "82"
+	case toastWarning:
+		return "226"
+	case toastError:
+		return "196"
+	default:
+		return "86"
+	}
+}
+
+func (s toastSeverity) ttl() time.Duration {
+	switch s {
+	case toastWarning:
+		return 5 * time.Second
+	case toastError:
+		return 8 * time.Second
+	default:
+		return 3 * time.Second
+	}
+}
+
+// toast is a transient status message
+type toast struct {
+	message  string
+	severity toastSeverity
+	created  time.Time
+	expiry   time.Time
+	animated bool // Cycles colors and sparkles each tick
+}
+
+// Colors an animated toast cycles through
+var rainbow = []string{"196", "208", "226", "46", "51", "99", "201"}
+
+// pushToast queues a message; it stays visible until it expires or is dismissed
+func (m *model) pushToast(message string, severity toastSeverity) {
+	now := time.Now()
+	t := toast{message: message, severity: severity, created: now, expiry: now.Add(severity.ttl())}
+	m.toasts = append(m.toasts, t)
+	m.toastLog = append(m.toastLog, t)
+	if overflow := len(m.toastLog) - maxToastLog; overflow > 0 {
+		m.toastLog = m.toastLog[overflow:]
+	}
+}
+
+// pushAnimatedToast queues a celebratory toast that sparkles while visible
+func (m *model) pushAnimatedToast(message string) {
+	m.pushToast(message, toastSuccess)
+	t := &m.toasts[len(m.toasts)-1]
+	t.animated = true
+	t.expiry = t.created.Add(levelUpLength)
+	m.toastLog[len(m.toastLog)-1] = *t
+}
+
+// expireToasts drops toasts whose time is up
+func (m *model) expireToasts(now time.Time) {
+	kept := m.toasts[:0]
+	for _, t := range m.toasts {
+		if now.Before(t.expiry) {
+			kept = append(kept, t)
+		}
+	}
+	m.toasts = kept
+}
+
+// dismissToast removes the newest toast, revealing any older ones behind it
+func (m *model) dismissToast() bool {
+	if len(m.toasts) == 0 {
+		return false
+	}
+	m.toasts = m.toasts[:len(m.toasts)-1]
+	return true
+}
+
+// renderToasts renders the newest toasts, newest first
+func (m model) renderToasts() string {
+	if len(m.toasts) == 0 {
+		return ""
+	}
+	var lines []string
+	for i := len(m.toasts) - 1; i >= 0 && len(lines) < maxVisibleToasts; i-- {
+		t := m.toasts[i]
+		if t.animated {
+			frame := max(int(m.lastTick.Sub(t.created)/time.Second), 0)
+			sparkle := []string{"✨", "🎉", "⭐", "🌟"}[frame%4]
+			style := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(rainbow[frame%len(rainbow)]))
+			lines = append(lines, "> "+style.Render(sparkle+" "+t.message+" "+sparkle))
+			continue
+		}
+		lines = append(lines, "> "+lipgloss.NewStyle().Foreground(lipgloss.Color(t.severity.color())).Render(t.message))
+	}
+	if hidden := len(m.toasts) - len(lines); hidden > 0 {
+		lines[len(lines)-1] += lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(fmt.Sprintf("  (+%d more, m: messages)", hidden))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m model) handleToastLogKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "m", "esc", "q":
+		m.showToastLog = false
+		m.toastLogScroll = 0
+	case "up", "k":
+		if m.toastLogScroll > 0 {
+			m.toastLogScroll--
+		}
+	case "down", "j":
+		if m.toastLogScroll < len(m.toastLog)-1 {
+			m.toastLogScroll++
+		}
+	}
+	return m, nil
+}
+
+// toastLogView is the scrollback popup of past messages, newest first
+func (m model) toastLogView() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("105"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	lines := []string{titleStyle.Render(fmt.Sprintf("💬 Messages (%d)", len(m.toastLog))), ""}
+	if len(m.toastLog) == 0 {
+		lines = append(lines, dimStyle.Render("  No messages yet"))
+	}
+
+	visible := max(m.getContentHeight()-2, 1)
+	shown := 0
+	for i := len(m.toastLog) - 1 - m.toastLogScroll; i >= 0 && shown < visible; i-- {
+		t := m.toastLog[i]
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color(t.severity.color()))
+		lines = append(lines, "  "+dimStyle.Render(t.created.Format("15:04:05"))+"  "+style.Render(t.message))
+		shown++
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(0, 1).
+		Width(m.getSafeWidth() - 2).
+		Render(strings.Join(lines, "\n"))
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -11,35 +13,78 @@ import (
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case statusMsg:
-		m.statusMsg = msg.message
-		m.statusColor = msg.color
-		m.statusExpiry = time.Now().Add(3 * time.Second)
+		m.pushToast(msg.message, msg.severity)
 		return m, nil
 
 	case tickMsg:
 		m.lastTick = time.Time(msg)
+		m.expireToasts(m.lastTick)
+
+		// Pick up changes written by CLI commands (e.g. `lif ack`). While an
+		// overlay points into the data the reload waits, and so does every
+		// save below, so the CLI's write isn't overwritten in the meantime.
+		if dataChangedOnDisk() {
+			if m.holdsData() {
+				return m, tickCmd()
+			}
+			m.data = loadData()
+			m.refreshRows()
+			m.achievementEvent(eventAny)
+		}
+		m.checkQuests()
+		m.checkLevelUp(m.lastTick)
 
 		// Check for daily task reset (runs every tick but only resets when needed)
 		if resetDailyTasks(&m.data) {
 			m.tables[0].SetRows(m.dailyRows())
-			m.statusMsg = "🌅 Daily tasks reset at 3AM"
-			m.statusColor = "82"
-			m.statusExpiry = time.Now().Add(5 * time.Second)
+			m.pushToast("🌅 New day: daily tasks reset", toastSuccess)
+			if recap := m.data.Health.Recap; recap.Day == logicalDay(time.Now()) && recap.Damage > 0 {
+				m.pushToast(fmt.Sprintf("🩸 Missed dailies cost %d HP", recap.Damage), toastWarning)
+			}
 			saveData(m.data)
+			// Last week may have just become a perfect one
+			m.achievementEvent(eventCompletion)
 		}
 
 		// Check for reminder notifications (only for active reminders)
+		now := time.Now()
 		for i, reminder := range m.data.Reminders {
-			if !reminder.TargetTime.IsZero() && !reminder.Notified && reminder.Status == "active" && time.Now().After(reminder.TargetTime) {
+			if reminder.Mode != "" {
+				m.tickTimer(&m.data.Reminders[i], now)
+				continue
+			}
+			if !reminder.TargetTime.IsZero() && !reminder.Notified && reminder.Status == "active" && now.After(reminder.TargetTime) {
 				m.data.Reminders[i].Notified = true
 				m.data.Reminders[i].Status = "expired"
-				sendNotification("Reminder", reminder.Reminder)
-				m.statusMsg = fmt.Sprintf("🔔 Reminder: %s", reminder.Reminder)
-				m.statusColor = "226"
-				m.statusExpiry = time.Now().Add(5 * time.Second)
-				saveData(m.data)
+				m.fireReminder(&m.data.Reminders[i], now)
+			} else if reminderRepeatDue(reminder, now) {
+				m.fireReminder(&m.data.Reminders[i], now)
+			} else if reminder.Status == "active" && !reminder.TargetTime.IsZero() {
+				if offset, ok := firePreAlerts(m.data.Reminders[i].PreAlerts, reminder.TargetTime, now); ok {
+					m.firePreAlert("Upcoming reminder", reminder.Reminder, offset, "prealert", reminder.ID, reminder.Urgent)
+				}
 			}
 		}
+
+		// Pre-alerts before rolling todo deadlines
+		for i, todo := range m.data.RollingTodos {
+			if deadline, ok := parseDeadline(todo.Deadline); ok {
+				if offset, ok := firePreAlerts(m.data.RollingTodos[i].PreAlerts, deadline, now); ok {
+					m.firePreAlert("Todo due soon", todo.Task, offset, "todo", todo.ID, false)
+					m.tables[1].SetRows(m.rollingRows())
+				}
+			}
+		}
+
+		// Nudge incomplete dailies as their due time approaches and passes
+		m.nudgeDailies(now)
+		m.tickRoutine(now)
+
+		// Quiet hours ended: deliver whatever was held back
+		if m.deliverQuietDigest(now) {
+			m.pushToast("🌙 Quiet hours over, sent notification digest", toastInfo)
+			saveData(m.data)
+		}
 		m.tables[2].SetRows(m.reminderRows())
 		return m, tickCmd()
 
@@ -76,6 +121,28 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.showInbox {
+			return m.handleInboxKeys(msg)
+		}
+		if m.showBackfill {
+			return m.handleBackfillKeys(msg)
+		}
+		if m.showHeatmap {
+			return m.handleHeatmapKeys(msg)
+		}
+		if m.showRoutine {
+			return m.handleRoutineKeys(msg)
+		}
+		if m.showTrophies {
+			return m.handleTrophyKeys(msg)
+		}
+		if m.showShop {
+			return m.handleShopKeys(msg)
+		}
+		if m.showToastLog {
+			return m.handleToastLogKeys(msg)
+		}
+
 		// Handle search mode for Reference tab
 		if m.searchActive && m.activeTab == 5 {
 			switch msg.String() {
@@ -127,11 +194,17 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.activeTab = 1
 			}
 		case "up", "k":
-			if m.activeTab > 1 && m.activeTab < 6 {
+			if m.activeTab == 1 && m.homeCursor > 0 {
+				m.homeCursor--
+			} else if m.activeTab > 1 && m.activeTab < 6 {
 				m.tables[m.activeTab-2], _ = m.tables[m.activeTab-2].Update(msg)
 			}
 		case "down", "j":
-			if m.activeTab > 1 && m.activeTab < 6 {
+			if m.activeTab == 1 {
+				if expired, active := m.homeReminders(); m.homeCursor < len(expired)+len(active)-1 {
+					m.homeCursor++
+				}
+			} else if m.activeTab > 1 && m.activeTab < 6 {
 				m.tables[m.activeTab-2], _ = m.tables[m.activeTab-2].Update(msg)
 			}
 		case "e":
@@ -140,11 +213,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "n":
 			if m.confirmDelete {
-				m.confirmDelete = false
+				m.confirmDelete, m.confirmDone = false, false
 				m.deleteTarget = ""
-				m.statusMsg = "Delete cancelled"
-				m.statusColor = "86"
-				m.statusExpiry = time.Now().Add(2 * time.Second)
+				m.pushToast("Cancelled", toastInfo)
 			} else if m.activeTab > 1 && m.activeTab < 6 {
 				m.addNew()
 			}
@@ -157,11 +228,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.confirmDeleteSelected()
 			}
 		case "y":
-			if m.confirmDelete {
+			if m.confirmDone {
+				m.completeSelectedTodo()
+			} else if m.confirmDelete {
 				m.deleteSelected()
-				m.confirmDelete = false
-				m.deleteTarget = ""
 			}
+			m.confirmDelete, m.confirmDone = false, false
+			m.deleteTarget = ""
 		case "s":
 			if m.activeTab == 4 {
 				m.toggleReminderStatus("start")
@@ -170,13 +243,109 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.cycleSortColumn()
 			}
 		case "p":
-			if m.activeTab == 4 {
+			if m.activeTab == 2 {
+				m.pauseSelected()
+			} else if m.activeTab == 4 {
 				m.toggleReminderStatus("pause")
 			}
+		case "v":
+			if m.activeTab == 2 {
+				m.archiveSelected()
+			}
+		case "V":
+			if m.activeTab == 2 {
+				m.toggleArchiveView()
+			}
+		case "g":
+			if m.activeTab == 2 {
+				m.toggleGroupByRoutine()
+			}
+		case "o":
+			if m.activeTab == 2 {
+				m.toggleRoutineCollapse()
+			}
+		case "R":
+			if m.activeTab == 1 || m.activeTab == 2 {
+				m.runSelectedRoutine()
+			}
+		case "T":
+			if m.activeTab == 1 {
+				m.showTrophies = true
+			}
 		case "r":
-			if m.activeTab == 4 {
+			if m.confirmDelete {
+				if m.deleteLinked > 0 {
+					m.deleteSelectedWithReminders()
+					m.confirmDelete = false
+					m.deleteTarget = ""
+				}
+			} else if m.activeTab == 4 {
 				m.toggleReminderStatus("reset")
 			}
+		case "B":
+			if m.activeTab == 2 {
+				m.openBackfill()
+			}
+		case "+", "=":
+			if m.activeTab == 2 {
+				m.adjustSelected(1)
+			}
+		case "-":
+			if m.activeTab == 2 {
+				m.adjustSelected(-1)
+			}
+		case "x":
+			if m.activeTab == 2 {
+				m.skipSelected()
+			} else if m.activeTab == 4 {
+				m.acknowledgeSelected()
+			}
+		case "l":
+			if m.activeTab == 4 {
+				m.lapSelected()
+			}
+		case "b":
+			if m.activeTab == 2 || m.activeTab == 3 {
+				m.addLinkedReminder()
+			}
+		case "c":
+			if m.activeTab == 1 {
+				if reminder, ok := m.selectedHomeReminder(); ok {
+					m.completeLinked(reminder.ID)
+				}
+			} else if m.activeTab == 4 {
+				if cursor := m.tables[2].Cursor(); cursor < len(m.data.Reminders) {
+					m.completeLinked(m.data.Reminders[cursor].ID)
+				}
+			}
+		case "esc":
+			if m.confirmDelete {
+				m.confirmDelete, m.confirmDone = false, false
+				m.deleteTarget = ""
+				m.pushToast("Cancelled", toastInfo)
+			} else {
+				m.dismissToast()
+			}
+		case "m":
+			m.showToastLog = true
+			m.toastLogScroll = 0
+			return m, nil
+		case "$":
+			m.openShop()
+			return m, nil
+		case "i":
+			m.showInbox = true
+			m.inboxCursor = 0
+			return m, nil
+		case "z":
+			// Toggle do-not-disturb
+			m.data.Settings.Quiet.Manual = !m.data.Settings.Quiet.Manual
+			saveData(m.data)
+			if m.data.Settings.Quiet.Manual {
+				m.pushToast("🌙 Do not disturb on", toastInfo)
+			} else {
+				m.pushToast("🔔 Do not disturb off", toastInfo)
+			}
 		case "/":
 			// Activate search for Reference tab
 			if m.activeTab == 5 {
@@ -184,10 +353,19 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.searchInput.Focus()
 				return m, nil
 			}
-		case " ", "enter":
-			// Toggle completion for dailies
+		case " ":
+			// Toggle completion for dailies, complete rolling todos after a confirm
 			if m.activeTab == 2 {
 				m.toggleCompletion()
+			} else if m.activeTab == 3 && !m.confirmDelete {
+				m.confirmDoneSelected()
+			}
+		case "enter":
+			// Heatmap detail for dailies, complete rolling todos after a confirm
+			if m.activeTab == 2 {
+				m.openHeatmap()
+			} else if m.activeTab == 3 && !m.confirmDelete {
+				m.confirmDoneSelected()
 			}
 
 		}
@@ -196,17 +374,28 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// holdsData reports whether an open form, confirmation or overlay refers to
+// items in m.data by index or ID, so reloading from disk has to wait
+func (m model) holdsData() bool {
+	return m.editing || m.confirmDelete || m.showBackfill || m.showHeatmap || m.showRoutine || m.showInbox
+}
+
 func (m model) handleEditingKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
 		m.editing = false
 		m.inputs = nil
-		return m, showStatus("❌ Edit cancelled", "196")
+		m.linkType, m.linkID = "", 0
+		return m, showStatus("❌ Edit cancelled", toastWarning)
 	case "enter":
-		m.saveEdit()
+		saved := m.saveEdit()
 		m.editing = false
 		m.inputs = nil
-		return m, showStatus("✅ Changes saved", "82")
+		m.linkType, m.linkID = "", 0
+		if !saved {
+			return m, nil
+		}
+		return m, showStatus("✅ Changes saved", toastSuccess)
 	case "tab":
 		if len(m.inputs) > 0 {
 			m.editingField = (m.editingField + 1) % len(m.inputs)
@@ -241,9 +430,9 @@ func (m *model) startEditing() {
 
 	switch m.editingTab {
 	case 2: // Dailies
-		if m.editingRow < len(m.data.Dailies) {
+		if _, ok := m.selectedSingleDaily(); ok {
 			daily := m.data.Dailies[m.editingRow]
-			m.inputs = make([]textinput.Model, 4)
+			m.inputs = make([]textinput.Model, 7)
 			m.inputs[0] = textinput.New()
 			m.inputs[0].SetValue(daily.Task)
 			m.inputs[0].Focus()
@@ -253,11 +442,19 @@ func (m *model) startEditing() {
 			m.inputs[2].SetValue(daily.Category)
 			m.inputs[3] = textinput.New()
 			m.inputs[3].SetValue(daily.Deadline)
+			m.inputs[4] = textinput.New()
+			m.inputs[4].SetValue(daily.Schedule)
+			m.inputs[5] = textinput.New()
+			m.inputs[5].SetValue(formatTarget(daily))
+			m.inputs[6] = textinput.New()
+			if daily.Kind == kindAvoid {
+				m.inputs[6].SetValue("y")
+			}
 		}
 	case 3: // Rolling Todos
 		if m.editingRow < len(m.data.RollingTodos) {
 			todo := m.data.RollingTodos[m.editingRow]
-			m.inputs = make([]textinput.Model, 4)
+			m.inputs = make([]textinput.Model, 5)
 			m.inputs[0] = textinput.New()
 			m.inputs[0].SetValue(todo.Task)
 			m.inputs[0].Focus()
@@ -267,11 +464,13 @@ func (m *model) startEditing() {
 			m.inputs[2].SetValue(todo.Category)
 			m.inputs[3] = textinput.New()
 			m.inputs[3].SetValue(todo.Deadline)
+			m.inputs[4] = textinput.New()
+			m.inputs[4].SetValue(formatPreAlerts(todo.PreAlerts))
 		}
 	case 4: // Reminders
 		if m.editingRow < len(m.data.Reminders) {
 			reminder := m.data.Reminders[m.editingRow]
-			m.inputs = make([]textinput.Model, 3)
+			m.inputs = make([]textinput.Model, 9)
 			m.inputs[0] = textinput.New()
 			m.inputs[0].SetValue(reminder.Reminder)
 			m.inputs[0].Focus()
@@ -279,6 +478,24 @@ func (m *model) startEditing() {
 			m.inputs[1].SetValue(reminder.Note)
 			m.inputs[2] = textinput.New()
 			m.inputs[2].SetValue(reminder.AlarmOrCountdown)
+			m.inputs[3] = textinput.New()
+			m.inputs[3].SetValue(reminder.RepeatEvery)
+			m.inputs[4] = textinput.New()
+			if reminder.Escalate {
+				m.inputs[4].SetValue("y")
+			}
+			m.inputs[5] = textinput.New()
+			m.inputs[5].SetValue(reminder.Sound)
+			m.inputs[6] = textinput.New()
+			if reminder.Volume > 0 {
+				m.inputs[6].SetValue(strconv.Itoa(reminder.Volume))
+			}
+			m.inputs[7] = textinput.New()
+			m.inputs[7].SetValue(formatPreAlerts(reminder.PreAlerts))
+			m.inputs[8] = textinput.New()
+			if reminder.Urgent {
+				m.inputs[8].SetValue("y")
+			}
 		}
 	case 5: // Reference
 		if m.editingRow < len(m.data.Reference) {
@@ -297,6 +514,10 @@ func (m *model) startEditing() {
 			m.inputs[4].SetValue(item.Meaning)
 		}
 	}
+	if len(m.inputs) == 0 {
+		// Nothing under the cursor to edit
+		m.editing = false
+	}
 }
 
 func (m *model) addNew() {
@@ -307,19 +528,19 @@ func (m *model) addNew() {
 
 	switch m.activeTab {
 	case 2: // Dailies
-		m.inputs = make([]textinput.Model, 4)
+		m.inputs = make([]textinput.Model, 7)
 		for i := range m.inputs {
 			m.inputs[i] = textinput.New()
 		}
 		m.inputs[0].Focus()
 	case 3: // Rolling Todos
-		m.inputs = make([]textinput.Model, 4)
+		m.inputs = make([]textinput.Model, 5)
 		for i := range m.inputs {
 			m.inputs[i] = textinput.New()
 		}
 		m.inputs[0].Focus()
 	case 4: // Reminders
-		m.inputs = make([]textinput.Model, 3)
+		m.inputs = make([]textinput.Model, 9)
 		for i := range m.inputs {
 			m.inputs[i] = textinput.New()
 		}
@@ -333,58 +554,115 @@ func (m *model) addNew() {
 	}
 }
 
-func (m *model) saveEdit() {
+// saveEdit applies the form; it returns false if the change was refused
+func (m *model) saveEdit() bool {
 	switch m.editingTab {
 	case 2: // Dailies
+		target, unit, step, ok := parseTarget(m.inputs[5].Value())
+		if !ok {
+			m.pushToast(fmt.Sprintf("⚠️ Couldn't read target %q, try \"8 glasses\" or \"10k steps\"", m.inputs[5].Value()), toastWarning)
+		}
 		if m.editingRow == -1 {
 			// New item
 			newDaily := Daily{
-				ID:            len(m.data.Dailies) + 1,
+				ID:            newDailyID(&m.data),
 				Task:          normalizeText(m.inputs[0].Value()),
 				Priority:      normalizePriority(m.inputs[1].Value()),
 				Category:      normalizeText(m.inputs[2].Value()),
-				Deadline:      m.inputs[3].Value(),
+				Deadline:      normalizeDailyDeadline(m.inputs[3].Value()),
+				Schedule:      normalizeSchedule(m.inputs[4].Value()),
 				Status:        "INCOMPLETE",
 				LastCompleted: time.Time{},
+				History:       map[string]DayRecord{},
+				Target:        target,
+				Unit:          unit,
+				Step:          step,
+			}
+			if parseYesNo(m.inputs[6].Value()) {
+				newDaily.Kind = kindAvoid
+				newDaily.Since = logicalDay(time.Now())
+				newDaily.Status = "DONE"
 			}
 			m.data.Dailies = append(m.data.Dailies, newDaily)
 		} else {
 			// Edit existing
-			m.data.Dailies[m.editingRow].Task = normalizeText(m.inputs[0].Value())
-			m.data.Dailies[m.editingRow].Priority = normalizePriority(m.inputs[1].Value())
-			m.data.Dailies[m.editingRow].Category = normalizeText(m.inputs[2].Value())
-			m.data.Dailies[m.editingRow].Deadline = m.inputs[3].Value()
+			edited := m.data.Dailies[m.editingRow]
+			edited.Task = normalizeText(m.inputs[0].Value())
+			edited.Priority = normalizePriority(m.inputs[1].Value())
+			edited.Category = normalizeText(m.inputs[2].Value())
+			edited.Deadline = normalizeDailyDeadline(m.inputs[3].Value())
+			edited.Schedule = normalizeSchedule(m.inputs[4].Value())
+			if ok {
+				edited.Target = target
+				edited.Unit = unit
+				edited.Step = step
+			}
+			setDailyKind(&edited, parseYesNo(m.inputs[6].Value()))
+			refreshStreak(&edited, logicalDay(time.Now()))
+			if err := checkSpendable(m.data, recomputeLoss(m.data, edited)); err != nil {
+				m.pushToast("⚠️ "+err.Error(), toastWarning)
+				return false
+			}
+			m.data.Dailies[m.editingRow] = edited
+			recomputeXP(&m.data)
+			rearmLinked(&m.data, linkDaily, edited.ID)
+			m.tables[2].SetRows(m.reminderRows())
 		}
 		m.tables[0].SetRows(m.dailyRows())
 	case 3: // Rolling Todos
 		if m.editingRow == -1 {
 			newTodo := RollingTodo{
-				ID:       len(m.data.RollingTodos) + 1,
-				Task:     normalizeText(m.inputs[0].Value()),
-				Priority: normalizePriority(m.inputs[1].Value()),
-				Category: normalizeText(m.inputs[2].Value()),
-				Deadline: m.inputs[3].Value(),
+				ID:        newTodoID(&m.data),
+				Task:      normalizeText(m.inputs[0].Value()),
+				Priority:  normalizePriority(m.inputs[1].Value()),
+				Category:  normalizeText(m.inputs[2].Value()),
+				Deadline:  m.inputs[3].Value(),
+				PreAlerts: parsePreAlerts(m.inputs[4].Value()),
+			}
+			if deadline, ok := parseDeadline(newTodo.Deadline); ok {
+				armPreAlerts(newTodo.PreAlerts, deadline, time.Now())
 			}
 			m.data.RollingTodos = append(m.data.RollingTodos, newTodo)
 		} else {
-			m.data.RollingTodos[m.editingRow].Task = normalizeText(m.inputs[0].Value())
-			m.data.RollingTodos[m.editingRow].Priority = normalizePriority(m.inputs[1].Value())
-			m.data.RollingTodos[m.editingRow].Category = normalizeText(m.inputs[2].Value())
-			m.data.RollingTodos[m.editingRow].Deadline = m.inputs[3].Value()
+			todo := &m.data.RollingTodos[m.editingRow]
+			todo.Task = normalizeText(m.inputs[0].Value())
+			todo.Priority = normalizePriority(m.inputs[1].Value())
+			todo.Category = normalizeText(m.inputs[2].Value())
+			todo.Deadline = m.inputs[3].Value()
+			todo.PreAlerts = parsePreAlerts(m.inputs[4].Value())
+			if deadline, ok := parseDeadline(todo.Deadline); ok {
+				armPreAlerts(todo.PreAlerts, deadline, time.Now())
+			}
+			rearmLinked(&m.data, linkTodo, todo.ID)
+			m.tables[2].SetRows(m.reminderRows())
 		}
 		m.tables[1].SetRows(m.rollingRows())
 	case 4: // Reminders
 		if m.editingRow == -1 {
 			newReminder := Reminder{
-				ID:               len(m.data.Reminders) + 1,
+				ID:               nextID(m.data.Reminders, func(r Reminder) int { return r.ID }),
 				Reminder:         normalizeText(m.inputs[0].Value()),
 				Note:             normalizeText(m.inputs[1].Value()),
 				AlarmOrCountdown: m.inputs[2].Value(),
+				RepeatEvery:      strings.TrimSpace(m.inputs[3].Value()),
+				Escalate:         parseYesNo(m.inputs[4].Value()),
+				Sound:            strings.TrimSpace(m.inputs[5].Value()),
+				Volume:           parseVolume(m.inputs[6].Value()),
+				PreAlerts:        parsePreAlerts(m.inputs[7].Value()),
+				Urgent:           parseYesNo(m.inputs[8].Value()),
 				CreatedAt:        time.Now(),
 				Notified:         false,
+				LinkType:         m.linkType,
+				LinkID:           m.linkID,
 			}
-			// Parse countdown or alarm
-			if targetTime, isCountdown := parseCountdown(m.inputs[2].Value()); isCountdown {
+			// Parse timer mode, countdown, alarm or offset before a linked deadline
+			if _, _, isTimer := parseTimerMode(m.inputs[2].Value()); isTimer {
+				startTimer(&newReminder, time.Now())
+			} else if targetTime, isLinked := linkedTarget(m.data, newReminder); isLinked {
+				newReminder.TargetTime = targetTime
+				newReminder.IsCountdown = false
+				newReminder.Status = "active"
+			} else if targetTime, isCountdown := parseCountdown(m.inputs[2].Value()); isCountdown {
 				newReminder.TargetTime = targetTime
 				newReminder.IsCountdown = true
 				newReminder.Status = "active"
@@ -393,21 +671,43 @@ func (m *model) saveEdit() {
 				newReminder.IsCountdown = false
 				newReminder.Status = "active"
 			}
+			armPreAlerts(newReminder.PreAlerts, newReminder.TargetTime, time.Now())
 			m.data.Reminders = append(m.data.Reminders, newReminder)
 		} else {
+			previousTimer := m.data.Reminders[m.editingRow].AlarmOrCountdown
 			m.data.Reminders[m.editingRow].Reminder = normalizeText(m.inputs[0].Value())
 			m.data.Reminders[m.editingRow].Note = normalizeText(m.inputs[1].Value())
 			m.data.Reminders[m.editingRow].AlarmOrCountdown = m.inputs[2].Value()
-			// Re-parse countdown or alarm when editing
-			if targetTime, isCountdown := parseCountdown(m.inputs[2].Value()); isCountdown {
+			m.data.Reminders[m.editingRow].RepeatEvery = strings.TrimSpace(m.inputs[3].Value())
+			m.data.Reminders[m.editingRow].Escalate = parseYesNo(m.inputs[4].Value())
+			m.data.Reminders[m.editingRow].Sound = strings.TrimSpace(m.inputs[5].Value())
+			m.data.Reminders[m.editingRow].Volume = parseVolume(m.inputs[6].Value())
+			m.data.Reminders[m.editingRow].PreAlerts = parsePreAlerts(m.inputs[7].Value())
+			m.data.Reminders[m.editingRow].Urgent = parseYesNo(m.inputs[8].Value())
+			armPreAlerts(m.data.Reminders[m.editingRow].PreAlerts, m.data.Reminders[m.editingRow].TargetTime, time.Now())
+			// Re-parse timer, countdown or alarm when editing; running timers
+			// only restart when their spec changed
+			if _, _, isTimer := parseTimerMode(m.inputs[2].Value()); isTimer {
+				if m.data.Reminders[m.editingRow].Mode == "" || previousTimer != m.inputs[2].Value() {
+					startTimer(&m.data.Reminders[m.editingRow], time.Now())
+				}
+			} else if targetTime, isLinked := linkedTarget(m.data, m.data.Reminders[m.editingRow]); isLinked {
+				m.data.Reminders[m.editingRow].Mode = ""
+				m.data.Reminders[m.editingRow].TargetTime = targetTime
+				m.data.Reminders[m.editingRow].IsCountdown = false
+				resetNotifyState(&m.data.Reminders[m.editingRow])
+				m.data.Reminders[m.editingRow].Status = "active"
+			} else if targetTime, isCountdown := parseCountdown(m.inputs[2].Value()); isCountdown {
+				m.data.Reminders[m.editingRow].Mode = ""
 				m.data.Reminders[m.editingRow].TargetTime = targetTime
 				m.data.Reminders[m.editingRow].IsCountdown = true
-				m.data.Reminders[m.editingRow].Notified = false
+				resetNotifyState(&m.data.Reminders[m.editingRow])
 				m.data.Reminders[m.editingRow].Status = "active"
 			} else if targetTime, isAlarm := parseAlarmTime(m.inputs[2].Value()); isAlarm {
+				m.data.Reminders[m.editingRow].Mode = ""
 				m.data.Reminders[m.editingRow].TargetTime = targetTime
 				m.data.Reminders[m.editingRow].IsCountdown = false
-				m.data.Reminders[m.editingRow].Notified = false
+				resetNotifyState(&m.data.Reminders[m.editingRow])
 				m.data.Reminders[m.editingRow].Status = "active"
 			}
 		}
@@ -415,7 +715,7 @@ func (m *model) saveEdit() {
 	case 5: // Reference
 		if m.editingRow == -1 {
 			newItem := ReferenceItem{
-				ID:      len(m.data.Reference) + 1,
+				ID:      nextID(m.data.Reference, func(r ReferenceItem) int { return r.ID }),
 				Lang:    normalizeText(m.inputs[0].Value()),
 				Command: normalizeText(m.inputs[1].Value()),
 				Usage:   normalizeText(m.inputs[2].Value()),
@@ -431,23 +731,28 @@ func (m *model) saveEdit() {
 			m.data.Reference[m.editingRow].Meaning = normalizeText(m.inputs[4].Value())
 		}
 		m.tables[3].SetRows(m.referenceRows())
+		defer m.achievementEvent(eventReference)
 	}
 
 	saveData(m.data)
+	return true
 }
 
 func (m *model) confirmDeleteSelected() {
 	cursor := m.tables[m.activeTab-2].Cursor()
 	var itemName string
 
+	m.deleteLinked = 0
 	switch m.activeTab {
 	case 2: // Dailies
-		if cursor < len(m.data.Dailies) {
+		if cursor, ok := m.selectedSingleDaily(); ok {
 			itemName = m.data.Dailies[cursor].Task
+			m.deleteLinked = linkedReminderCount(m.data, linkDaily, m.data.Dailies[cursor].ID)
 		}
 	case 3: // Rolling Todos
 		if cursor < len(m.data.RollingTodos) {
 			itemName = m.data.RollingTodos[cursor].Task
+			m.deleteLinked = linkedReminderCount(m.data, linkTodo, m.data.RollingTodos[cursor].ID)
 		}
 	case 4: // Reminders
 		if cursor < len(m.data.Reminders) {
@@ -470,40 +775,36 @@ func (m *model) deleteSelected() {
 
 	switch m.activeTab {
 	case 2: // Dailies
-		if cursor < len(m.data.Dailies) {
+		if cursor, ok := m.selectedDaily(); ok {
 			taskName := m.data.Dailies[cursor].Task
+			removeLinkedReminders(&m.data, linkDaily, m.data.Dailies[cursor].ID, false)
 			m.data.Dailies = append(m.data.Dailies[:cursor], m.data.Dailies[cursor+1:]...)
 			m.tables[0].SetRows(m.dailyRows())
-			m.statusMsg = fmt.Sprintf("🗑️ Deleted: %s", taskName)
-			m.statusColor = "196"
-			m.statusExpiry = time.Now().Add(3 * time.Second)
+			m.tables[2].SetRows(m.reminderRows())
+			m.pushToast(fmt.Sprintf("🗑️ Deleted: %s", taskName), toastWarning)
 		}
 	case 3: // Rolling Todos
 		if cursor < len(m.data.RollingTodos) {
 			taskName := m.data.RollingTodos[cursor].Task
+			removeLinkedReminders(&m.data, linkTodo, m.data.RollingTodos[cursor].ID, false)
 			m.data.RollingTodos = append(m.data.RollingTodos[:cursor], m.data.RollingTodos[cursor+1:]...)
 			m.tables[1].SetRows(m.rollingRows())
-			m.statusMsg = fmt.Sprintf("🗑️ Deleted: %s", taskName)
-			m.statusColor = "196"
-			m.statusExpiry = time.Now().Add(3 * time.Second)
+			m.tables[2].SetRows(m.reminderRows())
+			m.pushToast(fmt.Sprintf("🗑️ Deleted: %s", taskName), toastWarning)
 		}
 	case 4: // Reminders
 		if cursor < len(m.data.Reminders) {
 			reminderName := m.data.Reminders[cursor].Reminder
 			m.data.Reminders = append(m.data.Reminders[:cursor], m.data.Reminders[cursor+1:]...)
 			m.tables[2].SetRows(m.reminderRows())
-			m.statusMsg = fmt.Sprintf("🗑️ Deleted: %s", reminderName)
-			m.statusColor = "196"
-			m.statusExpiry = time.Now().Add(3 * time.Second)
+			m.pushToast(fmt.Sprintf("🗑️ Deleted: %s", reminderName), toastWarning)
 		}
 	case 5: // Reference
 		if cursor < len(m.data.Reference) {
 			itemName := m.data.Reference[cursor].Command
 			m.data.Reference = append(m.data.Reference[:cursor], m.data.Reference[cursor+1:]...)
 			m.tables[3].SetRows(m.referenceRows())
-			m.statusMsg = fmt.Sprintf("🗑️ Deleted: %s", itemName)
-			m.statusColor = "196"
-			m.statusExpiry = time.Now().Add(3 * time.Second)
+			m.pushToast(fmt.Sprintf("🗑️ Deleted: %s", itemName), toastWarning)
 		}
 	}
 
@@ -551,8 +852,7 @@ func (m *model) cycleSortColumn() {
 		direction = "↓"
 	}
 
-	m.statusMsg = fmt.Sprintf("Sorted by: %s %s", sortNames[tableIdx][m.sortColumn[tableIdx]], direction)
-	m.statusColor = "86"
+	m.pushToast(fmt.Sprintf("Sorted by: %s %s", sortNames[tableIdx][m.sortColumn[tableIdx]], direction), toastInfo)
 }
 
 func (m *model) toggleReminderStatus(action string) {
@@ -567,7 +867,15 @@ func (m *model) toggleReminderStatus(action string) {
 
 	reminder := &m.data.Reminders[cursor]
 	var statusMsg string
-	var statusColor string
+	var severity toastSeverity
+
+	if reminder.Mode != "" {
+		statusMsg, severity = toggleTimer(reminder, action, time.Now())
+		m.tables[2].SetRows(m.reminderRows())
+		saveData(m.data)
+		m.pushToast(statusMsg, severity)
+		return
+	}
 
 	switch action {
 	case "start":
@@ -578,25 +886,28 @@ func (m *model) toggleReminderStatus(action string) {
 				reminder.PausedRemaining = 0
 			}
 			reminder.Status = "active"
-			reminder.Notified = false
+			resetNotifyState(reminder)
 			statusMsg = fmt.Sprintf("▶️ Resumed: %s", reminder.Reminder)
-			statusColor = "82"
+			severity = toastSuccess
 		} else if reminder.Status == "inactive" {
 			reminder.Status = "active"
-			reminder.Notified = false
 			// Re-parse the alarm/countdown
-			if targetTime, isCountdown := parseCountdown(reminder.AlarmOrCountdown); isCountdown {
+			if targetTime, isLinked := linkedTarget(m.data, *reminder); isLinked {
+				reminder.TargetTime = targetTime
+				reminder.IsCountdown = false
+			} else if targetTime, isCountdown := parseCountdown(reminder.AlarmOrCountdown); isCountdown {
 				reminder.TargetTime = targetTime
 				reminder.IsCountdown = true
 			} else if targetTime, isAlarm := parseAlarmTime(reminder.AlarmOrCountdown); isAlarm {
 				reminder.TargetTime = targetTime
 				reminder.IsCountdown = false
 			}
+			resetNotifyState(reminder)
 			statusMsg = fmt.Sprintf("▶️ Started: %s", reminder.Reminder)
-			statusColor = "82"
+			severity = toastSuccess
 		} else {
 			statusMsg = fmt.Sprintf("⚠️ %s is already active", reminder.Reminder)
-			statusColor = "226"
+			severity = toastWarning
 		}
 
 	case "pause":
@@ -610,42 +921,161 @@ func (m *model) toggleReminderStatus(action string) {
 			}
 			reminder.Status = "paused"
 			statusMsg = fmt.Sprintf("⏸️ Paused: %s", reminder.Reminder)
-			statusColor = "226"
+			severity = toastWarning
 		} else {
 			statusMsg = fmt.Sprintf("⚠️ %s is not active", reminder.Reminder)
-			statusColor = "226"
+			severity = toastWarning
 		}
 
 	case "reset":
 		reminder.Status = "active"
-		reminder.Notified = false
 		reminder.PausedRemaining = 0 // Clear any paused time
 		// Re-parse and reset the target time
-		if targetTime, isCountdown := parseCountdown(reminder.AlarmOrCountdown); isCountdown {
+		if targetTime, isLinked := linkedTarget(m.data, *reminder); isLinked {
+			reminder.TargetTime = targetTime
+			reminder.IsCountdown = false
+		} else if targetTime, isCountdown := parseCountdown(reminder.AlarmOrCountdown); isCountdown {
 			reminder.TargetTime = targetTime
 			reminder.IsCountdown = true
 		} else if targetTime, isAlarm := parseAlarmTime(reminder.AlarmOrCountdown); isAlarm {
 			reminder.TargetTime = targetTime
 			reminder.IsCountdown = false
 		}
+		resetNotifyState(reminder)
 		statusMsg = fmt.Sprintf("🔄 Reset: %s", reminder.Reminder)
-		statusColor = "82"
+		severity = toastSuccess
+	}
+
+	m.tables[2].SetRows(m.reminderRows())
+	saveData(m.data)
+	m.pushToast(statusMsg, severity)
+}
+
+// fireReminder delivers a reminder notification, escalating on repeats
+func (m *model) fireReminder(reminder *Reminder, now time.Time) {
+	reminder.NotifyCount++
+	reminder.LastNotified = now
+	m.notify(Notification{
+		Title:      "Reminder",
+		Message:    reminder.Reminder,
+		Level:      reminderLevel(*reminder),
+		Sound:      reminder.Sound,
+		Volume:     reminder.Volume,
+		SourceType: "reminder",
+		SourceID:   reminder.ID,
+	}, reminder.Urgent)
+	if reminder.NotifyCount > 1 {
+		m.pushToast(fmt.Sprintf("🔔 Reminder (x%d): %s — press x to acknowledge", reminder.NotifyCount, reminder.Reminder), toastWarning)
+	} else {
+		m.pushToast(fmt.Sprintf("🔔 Reminder: %s", reminder.Reminder), toastWarning)
+	}
+	saveData(m.data)
+}
+
+// nudgeDailies notifies once when an incomplete daily is due soon and once
+// more when it is overdue, tracked per logical day
+func (m *model) nudgeDailies(now time.Time) {
+	today := logicalDay(now)
+	for i := range m.data.Dailies {
+		daily := &m.data.Dailies[i]
+		if daily.NudgeDay != today {
+			daily.NudgeDay = today
+			daily.NudgeStage = 0
+		}
+		stage := dailyNudgeStage(*daily, now)
+		if stage <= daily.NudgeStage {
+			continue
+		}
+		// Opening lif after the due time only sends the overdue nudge
+		daily.NudgeStage = stage
+
+		title, message := "Daily due soon", fmt.Sprintf("%s is due by %s", daily.Task, daily.Deadline)
+		severity := toastWarning
+		if stage == 2 {
+			title, message = "Daily overdue", fmt.Sprintf("%s was due at %s", daily.Task, daily.Deadline)
+			if daily.CurrentStreak > 0 {
+				message += fmt.Sprintf(", %d day streak at risk", daily.CurrentStreak)
+			}
+			severity = toastError
+		}
+		m.notify(Notification{
+			Title:      title,
+			Message:    message,
+			Level:      levelNormal,
+			SourceType: "daily",
+			SourceID:   daily.ID,
+		}, false)
+		m.pushToast(fmt.Sprintf("⏰ %s: %s", title, message), severity)
+		m.tables[0].SetRows(m.dailyRows())
+		saveData(m.data)
 	}
+}
+
+// firePreAlert announces that an item is due within offset
+func (m *model) firePreAlert(title, name, offset, sourceType string, sourceID int, urgent bool) {
+	message := fmt.Sprintf("%s in %s", name, offset)
+	m.notify(Notification{
+		Title:      title,
+		Message:    message,
+		Level:      levelNormal,
+		SourceType: sourceType,
+		SourceID:   sourceID,
+	}, urgent)
+	m.pushToast(fmt.Sprintf("⏰ %s: %s", title, message), toastWarning)
+	saveData(m.data)
+}
 
+func (m *model) acknowledgeSelected() {
+	cursor := m.tables[2].Cursor()
+	if cursor >= len(m.data.Reminders) {
+		return
+	}
+
+	reminder := &m.data.Reminders[cursor]
+	if reminder.Status != "expired" || reminder.Acknowledged {
+		m.pushToast(fmt.Sprintf("⚠️ %s has nothing to acknowledge", reminder.Reminder), toastWarning)
+		return
+	}
+
+	acknowledgeReminder(&m.data, reminder)
 	m.tables[2].SetRows(m.reminderRows())
 	saveData(m.data)
-	m.statusMsg = statusMsg
-	m.statusColor = statusColor
-	m.statusExpiry = time.Now().Add(3 * time.Second)
+	m.pushToast(fmt.Sprintf("✅ Acknowledged: %s", reminder.Reminder), toastSuccess)
+	m.achievementEvent(eventReminder)
+}
+
+// skipSelected marks the selected daily as not applicable today, or unskips it
+func (m *model) skipSelected() {
+	cursor, ok := m.selectedSingleDaily()
+	if !ok {
+		return
+	}
+
+	daily := &m.data.Dailies[cursor]
+	if daily.Kind == kindAvoid {
+		m.pushToast(fmt.Sprintf("⚠️ %s is an avoid habit, there's nothing to skip", daily.Task), toastWarning)
+		return
+	}
+	if daily.Status == "DONE" {
+		m.pushToast(fmt.Sprintf("⚠️ %s is already done today", daily.Task), toastWarning)
+		return
+	}
+	if toggleSkip(daily) {
+		m.pushToast(fmt.Sprintf("⏭️ Skipped today: %s (streak kept)", daily.Task), toastInfo)
+	} else {
+		m.pushToast(fmt.Sprintf("↩️ Unskipped: %s", daily.Task), toastInfo)
+	}
+	m.tables[0].SetRows(m.dailyRows())
+	saveData(m.data)
 }
 
 func (m *model) toggleCompletion() {
-	if m.activeTab != 2 || len(m.data.Dailies) == 0 {
+	if m.activeTab != 2 {
 		return
 	}
 
-	cursor := m.tables[0].Cursor()
-	if cursor >= len(m.data.Dailies) {
+	cursor, ok := m.selectedDaily()
+	if !ok {
 		return
 	}
 
@@ -654,29 +1084,60 @@ func (m *model) toggleCompletion() {
 
 	daily := &m.data.Dailies[cursor]
 
+	if r := m.collapsedRoutine(cursor); r != nil {
+		// A collapsed routine row runs the routine
+		m.startRoutine(r)
+		return
+	}
+	if daily.Kind == kindAvoid {
+		m.toggleSlipSelected(daily)
+		return
+	}
+
+	if daily.Target > 0 {
+		// Quantitative dailies toggle between nothing logged and the full target
+		if current == "DONE" {
+			if err := checkSpendable(m.data, amountLoss(m.data, *daily, 0)); err != nil {
+				m.pushToast("⚠️ "+err.Error(), toastWarning)
+				return
+			}
+			setAmount(&m.data, daily, 0)
+			m.pushToast(fmt.Sprintf("Progress cleared: %s", daily.Task), toastWarning)
+		} else {
+			setAmount(&m.data, daily, daily.Target)
+			m.pushToast(fmt.Sprintf("✅ Target reached: %s! %d day streak! 🔥", daily.Task, daily.CurrentStreak), toastSuccess)
+		}
+		m.tables[0].SetRows(m.dailyRows())
+		saveData(m.data)
+		m.achievementEvent(eventCompletion)
+		return
+	}
+
 	switch current {
 	case "DONE":
 		newStatus = "INCOMPLETE"
+		if err := checkSpendable(m.data, undoLoss(m.data, *daily)); err != nil {
+			m.pushToast("⚠️ "+err.Error(), toastWarning)
+			return
+		}
+		undoTaskStreak(&m.data, daily)
 		daily.LastCompleted = time.Time{} // Clear completion time
-		m.statusMsg = fmt.Sprintf("Task marked as %s", newStatus)
-		m.statusColor = "196"
+		m.pushToast(fmt.Sprintf("Task marked as %s", newStatus), toastWarning)
 	default:
 		newStatus = "DONE"
+		completeDaily(&m.data, daily)
 
-		// Update task streak (before setting LastCompleted so it can check previous value)
-		updateTaskStreak(daily)
-		daily.LastCompleted = time.Now() // Record completion time
-
-		if daily.CurrentStreak > 1 {
-			m.statusMsg = fmt.Sprintf("✅ Task marked as %s! %d day streak! 🔥", newStatus, daily.CurrentStreak)
+		if r, finished := updateRoutineStreak(&m.data, *daily); finished {
+			m.pushToast(fmt.Sprintf("🧭 %s complete! %d day routine streak 🔥", r.Name, r.CurrentStreak), toastSuccess)
+		} else if daily.CurrentStreak > 1 {
+			m.pushToast(fmt.Sprintf("✅ Task marked as %s! %d day streak! 🔥", newStatus, daily.CurrentStreak), toastSuccess)
 		} else {
-			m.statusMsg = fmt.Sprintf("✅ Task marked as %s!", newStatus)
+			m.pushToast(fmt.Sprintf("✅ Task marked as %s!", newStatus), toastSuccess)
 		}
-		m.statusColor = "82"
 	}
 
 	daily.Status = newStatus
 	m.tables[0].SetRows(m.dailyRows())
 	saveData(m.data)
-	m.statusExpiry = time.Now().Add(3 * time.Second)
+	m.achievementEvent(eventCompletion)
 }
@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"sort"
 	"strings"
 	"time"
 
@@ -13,7 +12,16 @@ import (
 func (m *model) dailyRows() []table.Row {
 	rows := []table.Row{}
 	sortDailies(m.data.Dailies, m.sortColumn[0], m.sortAscending[0])
-	for _, daily := range m.data.Dailies {
+	shown := partitionDailies(m.data.Dailies, m.showArchived)
+	if m.groupByRoutine && !m.showArchived {
+		shown = m.groupRoutines(shown)
+	}
+	m.dailyShown = shown
+	if cursor := m.tables[0].Cursor(); shown > 0 && cursor >= shown {
+		// Keep the cursor off the hidden dailies past the last row
+		m.tables[0].SetCursor(shown - 1)
+	}
+	for _, daily := range m.data.Dailies[:shown] {
 		priority := daily.Priority
 		if priority == "" {
 			priority = "MEDIUM"
@@ -34,21 +42,96 @@ func (m *model) dailyRows() []table.Row {
 
 		// Status: only color the status column, not the whole row
 		status := daily.Status
-		if status == "DONE" {
+		today := logicalDay(time.Now())
+		if daily.Archived {
+			status = "📦 ARCHIVED"
+		} else if daily.Paused {
+			status = "⏸️ PAUSED"
+		} else if daily.Kind == kindAvoid {
+			// Avoid habits are clean unless they slipped today
+			status = statusDoneStyle.Render("✓ CLEAN")
+			if daily.History[today].Status == daySlipped {
+				status = statusOverdueStyle.Render("✗ SLIPPED")
+			}
+		} else if status == "DONE" {
 			status = statusDoneStyle.Render("✓ DONE")
+			if daily.Target > 0 {
+				status = statusDoneStyle.Render("✓ " + progressText(daily, dayAmount(daily, today)))
+			}
 		} else {
-			status = "INCOMPLETE"
+			switch {
+			case daily.History[today].Status == daySkipped:
+				status = "⏭️ SKIPPED"
+			case daily.History[today].Status == dayVacation:
+				status = "🏖️ VACATION"
+			case !dueOn(daily, today):
+				status = "REST DAY"
+			case daily.Target > 0 && dailyNudgeStage(daily, time.Now()) == 2:
+				status = statusOverdueStyle.Render(progressText(daily, dayAmount(daily, today)))
+			case daily.Target > 0:
+				status = progressText(daily, dayAmount(daily, today))
+			case dailyNudgeStage(daily, time.Now()) == 2:
+				status = statusOverdueStyle.Render("OVERDUE " + daily.Deadline)
+			case dailyNudgeStage(daily, time.Now()) == 1:
+				status = statusPendingStyle.Render("DUE " + daily.Deadline)
+			case dailySchedule(daily).period() != periodDay:
+				status = "DUE THIS " + strings.ToUpper(dailySchedule(daily).period())
+			default:
+				status = "DUE TODAY"
+			}
 		}
 
-		streakDisplay := fmt.Sprintf("%d days", daily.CurrentStreak)
+		sched := dailySchedule(daily)
+		unit := "days"
+		switch {
+		case sched.kind == schedulePerWeek:
+			unit = "done"
+		case sched.period() != periodDay:
+			unit = sched.period() + "s"
+		}
+		label := sched.label()
+		if daily.Kind == kindAvoid {
+			unit, label = "days clean", "avoid"
+		}
+		streakDisplay := fmt.Sprintf("%d %s", daily.CurrentStreak, unit)
 		if daily.CurrentStreak > 0 {
-			streakDisplay = fmt.Sprintf("%d days 🔥", daily.CurrentStreak)
+			streakDisplay = fmt.Sprintf("%d %s 🔥", daily.CurrentStreak, unit)
+		}
+
+		task := normalizeText(daily.Task)
+		if daily.Kind == kindAvoid {
+			task = "🚫 " + task
+		}
+		category := normalizeText(daily.Category)
+		if m.groupByRoutine {
+			var collapsed *Routine
+			task, collapsed = m.routineRowTask(daily, task)
+			if collapsed != nil {
+				// One row stands in for the whole routine
+				done, total := m.routineCompletion(*collapsed)
+				category, label = collapsed.Window, "routine"
+				streak := routineStreak(&m.data, *collapsed, today)
+				streakDisplay = fmt.Sprintf("%d days", streak)
+				if streak > 0 {
+					streakDisplay += " 🔥"
+				}
+				status = fmt.Sprintf("%d/%d DONE", done, total)
+				if done == total {
+					status = statusDoneStyle.Render("✓ DONE")
+				}
+				rows = append(rows, table.Row{task, displayPriority, category, label, streakDisplay, fmt.Sprintf("%d", collapsed.BestStreak), status})
+				continue
+			}
+		}
+		if linkedReminderCount(m.data, linkDaily, daily.ID) > 0 {
+			task = "🔔 " + task
 		}
 
 		rows = append(rows, table.Row{
-			normalizeText(daily.Task),
+			task,
 			displayPriority,
-			normalizeText(daily.Category),
+			category,
+			label,
 			streakDisplay,
 			fmt.Sprintf("%d", daily.BestStreak),
 			status,
@@ -79,11 +162,25 @@ func (m *model) rollingRows() []table.Row {
 			displayPriority = "MEDIUM"
 		}
 
+		deadlineDisplay := todo.Deadline
+		if deadline, ok := parseDeadline(todo.Deadline); ok {
+			if preAlerted(todo.PreAlerts, deadline, time.Now()) {
+				deadlineDisplay = "⏰ " + todo.Deadline
+			} else if time.Now().After(deadline) {
+				deadlineDisplay = todo.Deadline + " (OVERDUE)"
+			}
+		}
+
+		task := normalizeText(todo.Task)
+		if linkedReminderCount(m.data, linkTodo, todo.ID) > 0 {
+			task = "🔔 " + task
+		}
+
 		rows = append(rows, table.Row{
-			normalizeText(todo.Task),
+			task,
 			displayPriority,
 			normalizeText(todo.Category),
-			todo.Deadline,
+			deadlineDisplay,
 		})
 	}
 	return rows
@@ -95,7 +192,9 @@ func (m *model) reminderRows() []table.Row {
 	for _, reminder := range m.data.Reminders {
 		// Display countdown/alarm time
 		displayTime := reminder.AlarmOrCountdown
-		if reminder.Status == "paused" && reminder.PausedRemaining > 0 {
+		if reminder.Mode != "" {
+			displayTime = timerDisplay(reminder, time.Now())
+		} else if reminder.Status == "paused" && reminder.PausedRemaining > 0 {
 			// Show paused remaining time
 			if reminder.IsCountdown {
 				displayTime = fmt.Sprintf("%s (PAUSED %s)", reminder.AlarmOrCountdown, reminder.PausedRemaining.Truncate(time.Second))
@@ -110,13 +209,23 @@ func (m *model) reminderRows() []table.Row {
 				} else {
 					displayTime = fmt.Sprintf("%s (%s)", reminder.AlarmOrCountdown, reminder.TargetTime.Format("15:04"))
 				}
+				if reminder.Status == "active" && preAlerted(reminder.PreAlerts, reminder.TargetTime, time.Now()) {
+					displayTime = "⏰ " + displayTime + " SOON"
+				}
+			} else if isRinging(reminder) {
+				displayTime = fmt.Sprintf("%s (RINGING x%d)", reminder.AlarmOrCountdown, reminder.NotifyCount)
 			} else {
 				displayTime = fmt.Sprintf("%s (EXPIRED)", reminder.AlarmOrCountdown)
 			}
 		}
 
+		name := normalizeText(reminder.Reminder)
+		if _, ok := linkedName(m.data, reminder.LinkType, reminder.LinkID); ok {
+			name = "🔗 " + name
+		}
+
 		rows = append(rows, table.Row{
-			normalizeText(reminder.Reminder),
+			name,
 			normalizeText(reminder.Note),
 			displayTime,
 		})
@@ -196,6 +305,20 @@ func (m model) View() string {
 		content = m.editView()
 	case m.showHelp:
 		content = m.helpView()
+	case m.showInbox:
+		content = m.inboxView()
+	case m.showBackfill:
+		content = m.backfillView()
+	case m.showHeatmap:
+		content = m.heatmapView()
+	case m.showRoutine:
+		content = m.routineView()
+	case m.showTrophies:
+		content = m.trophyView()
+	case m.showShop:
+		content = m.shopView()
+	case m.showToastLog:
+		content = m.toastLogView()
 	case m.activeTab == 1:
 		content = m.homeView()
 	case m.activeTab == 5:
@@ -231,6 +354,14 @@ func (m model) renderHeader() string {
 		Width(m.width)
 
 	title := "📋 lif - lucas is forgetful"
+	// Level and XP progress on the right of the title bar
+	badge := m.levelBadge()
+	if hp := m.hpBadge(); hp != "" {
+		badge = hp + "  " + badge
+	}
+	if gap := m.width - 2 - lipgloss.Width(title) - lipgloss.Width(badge); gap > 0 {
+		title += strings.Repeat(" ", gap) + badge
+	}
 
 	// Tab headers
 	tabs := []string{}
@@ -263,18 +394,67 @@ func (m model) renderStatusBar() string {
 		Width(m.width)
 
 	var commands []string
-	if m.activeTab == 1 {
+	if m.showInbox {
+		commands = append(commands, keyStyle.Render("↑↓")+colonStyle.Render(": ")+actionStyle.Render("navigate"))
+		commands = append(commands, keyStyle.Render("enter")+colonStyle.Render(": ")+actionStyle.Render("go to item"))
+		commands = append(commands, keyStyle.Render("a")+colonStyle.Render(": ")+actionStyle.Render("acknowledge"))
+		commands = append(commands, keyStyle.Render("r")+colonStyle.Render(": ")+actionStyle.Render("re-send"))
+		commands = append(commands, keyStyle.Render("s")+colonStyle.Render(": ")+actionStyle.Render("snooze 10m"))
+		commands = append(commands, keyStyle.Render("c")+colonStyle.Render(": ")+actionStyle.Render("complete linked"))
+		commands = append(commands, keyStyle.Render("esc")+colonStyle.Render(": ")+actionStyle.Render("close"))
+	} else if m.showHeatmap {
+		commands = append(commands, keyStyle.Render("←↑↓→")+colonStyle.Render(": ")+actionStyle.Render("pick day"))
+		commands = append(commands, keyStyle.Render("enter")+colonStyle.Render(": ")+actionStyle.Render("day detail"))
+		commands = append(commands, keyStyle.Render("[ ]")+colonStyle.Render(": ")+actionStyle.Render("prev/next habit"))
+		commands = append(commands, keyStyle.Render("a")+colonStyle.Render(": ")+actionStyle.Render("all habits"))
+		commands = append(commands, keyStyle.Render("esc")+colonStyle.Render(": ")+actionStyle.Render("close"))
+	} else if m.showRoutine {
+		commands = append(commands, keyStyle.Render("space")+colonStyle.Render(": ")+actionStyle.Render("done, next step"))
+		commands = append(commands, keyStyle.Render("n/p")+colonStyle.Render(": ")+actionStyle.Render("next/prev step"))
+		commands = append(commands, keyStyle.Render("esc")+colonStyle.Render(": ")+actionStyle.Render("leave routine"))
+	} else if m.showShop && m.shopAdding {
+		commands = append(commands, keyStyle.Render("enter")+colonStyle.Render(": ")+actionStyle.Render("add reward"))
+		commands = append(commands, keyStyle.Render("esc")+colonStyle.Render(": ")+actionStyle.Render("cancel"))
+	} else if m.showShop {
+		commands = append(commands, keyStyle.Render("↑↓")+colonStyle.Render(": ")+actionStyle.Render("select"))
+		commands = append(commands, keyStyle.Render("space")+colonStyle.Render(": ")+actionStyle.Render("buy"))
+		commands = append(commands, keyStyle.Render("n/d")+colonStyle.Render(": ")+actionStyle.Render("add/remove reward"))
+		commands = append(commands, keyStyle.Render("u")+colonStyle.Render(": ")+actionStyle.Render("undo purchase"))
+		commands = append(commands, keyStyle.Render("esc")+colonStyle.Render(": ")+actionStyle.Render("close"))
+	} else if m.showTrophies {
+		commands = append(commands, keyStyle.Render("esc")+colonStyle.Render(": ")+actionStyle.Render("close"))
+	} else if m.showBackfill {
+		commands = append(commands, keyStyle.Render("↑↓")+colonStyle.Render(": ")+actionStyle.Render("pick day"))
+		commands = append(commands, keyStyle.Render("space")+colonStyle.Render(": ")+actionStyle.Render("toggle done"))
+		commands = append(commands, keyStyle.Render("esc")+colonStyle.Render(": ")+actionStyle.Render("close"))
+	} else if m.activeTab == 1 {
 		commands = append(commands, keyStyle.Render("1-5")+colonStyle.Render(": ")+actionStyle.Render("navigate"))
+		commands = append(commands, keyStyle.Render("↑↓")+colonStyle.Render(": ")+actionStyle.Render("select reminder"))
+		commands = append(commands, keyStyle.Render("c")+colonStyle.Render(": ")+actionStyle.Render("complete linked"))
+		if len(m.data.Routines) > 0 {
+			commands = append(commands, keyStyle.Render("R")+colonStyle.Render(": ")+actionStyle.Render("run routine"))
+		}
+		commands = append(commands, keyStyle.Render("T")+colonStyle.Render(": ")+actionStyle.Render("trophies"))
 	} else {
 		commands = append(commands, keyStyle.Render("↑↓")+colonStyle.Render(": ")+actionStyle.Render("navigate"))
 		commands = append(commands, keyStyle.Render("e")+colonStyle.Render(": ")+actionStyle.Render("edit"))
 		commands = append(commands, keyStyle.Render("n/a")+colonStyle.Render(": ")+actionStyle.Render("add"))
 		commands = append(commands, keyStyle.Render("d")+colonStyle.Render(": ")+actionStyle.Render("delete"))
 		if m.activeTab == 2 {
-			commands = append(commands, keyStyle.Render("space/enter")+colonStyle.Render(": ")+actionStyle.Render("toggle done"))
+			commands = append(commands, keyStyle.Render("space")+colonStyle.Render(": ")+actionStyle.Render("toggle done"))
+			commands = append(commands, keyStyle.Render("enter")+colonStyle.Render(": ")+actionStyle.Render("heatmap"))
+			commands = append(commands, keyStyle.Render("b")+colonStyle.Render(": ")+actionStyle.Render("add reminder"))
+			commands = append(commands, keyStyle.Render("x")+colonStyle.Render(": ")+actionStyle.Render("skip"))
+			commands = append(commands, keyStyle.Render("+/-")+colonStyle.Render(": ")+actionStyle.Render("progress"))
+			commands = append(commands, keyStyle.Render("B")+colonStyle.Render(": ")+actionStyle.Render("past days"))
+			commands = append(commands, keyStyle.Render("p/v")+colonStyle.Render(": ")+actionStyle.Render("pause/archive"))
+			commands = append(commands, keyStyle.Render("V")+colonStyle.Render(": ")+actionStyle.Render("archived"))
+			commands = append(commands, keyStyle.Render("g/o/R")+colonStyle.Render(": ")+actionStyle.Render("routines"))
 			commands = append(commands, keyStyle.Render("s")+colonStyle.Render(": ")+actionStyle.Render("sort"))
 		}
 		if m.activeTab == 3 {
+			commands = append(commands, keyStyle.Render("space/enter")+colonStyle.Render(": ")+actionStyle.Render("complete"))
+			commands = append(commands, keyStyle.Render("b")+colonStyle.Render(": ")+actionStyle.Render("add reminder"))
 			commands = append(commands, keyStyle.Render("s")+colonStyle.Render(": ")+actionStyle.Render("sort"))
 		}
 		if m.activeTab == 5 {
@@ -285,16 +465,29 @@ func (m model) renderStatusBar() string {
 			commands = append(commands, keyStyle.Render("s")+colonStyle.Render(": ")+actionStyle.Render("start/resume"))
 			commands = append(commands, keyStyle.Render("p")+colonStyle.Render(": ")+actionStyle.Render("pause"))
 			commands = append(commands, keyStyle.Render("r")+colonStyle.Render(": ")+actionStyle.Render("reset"))
+			commands = append(commands, keyStyle.Render("x")+colonStyle.Render(": ")+actionStyle.Render("acknowledge"))
+			commands = append(commands, keyStyle.Render("c")+colonStyle.Render(": ")+actionStyle.Render("complete linked"))
 		}
 	}
 	commands = append(commands, keyStyle.Render("?")+colonStyle.Render(": ")+actionStyle.Render("help"))
 	commands = append(commands, keyStyle.Render("q")+colonStyle.Render(": ")+actionStyle.Render("quit"))
 	commandRow := strings.Join(commands, bulletStyle.Render(" • "))
 
-	// Status message (no expiry)
-	if m.statusMsg != "" {
-		statusMsgStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.statusColor))
-		commandRow += "\n> " + statusMsgStyle.Render(m.statusMsg)
+	// Do-not-disturb indicator
+	if quiet := m.data.Settings.Quiet; quiet.active(time.Now()) {
+		label := "🌙 quiet hours"
+		if quiet.Manual {
+			label = "🌙 DND"
+		}
+		if queued := len(m.data.QuietQueue); queued > 0 {
+			label += fmt.Sprintf(" (%d held)", queued)
+		}
+		commandRow = lipgloss.NewStyle().Foreground(lipgloss.Color("141")).Background(lipgloss.Color("236")).Render(label) + bulletStyle.Render(" • ") + commandRow
+	}
+
+	// Stacked toasts, newest first
+	if toasts := m.renderToasts(); toasts != "" {
+		commandRow += "\n" + toasts
 	}
 
 	return statusStyle.Render(commandRow)
@@ -316,12 +509,26 @@ func (m model) renderConfirmDeleteView() string {
 		Bold(true).
 		Foreground(lipgloss.Color("196")).
 		Render("⚠️  Confirm Deletion")
+	question := "Are you sure you want to delete:"
+	if m.confirmDone {
+		modalTitle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("46")).
+			Render("✅ Complete Todo")
+		question = "Complete and remove this todo and its reminders?"
+	}
+
+	actions := keyStyle.Render("[y]") + " " + actionStyle.Render("Confirm") + "  " +
+		keyStyle.Render("[n]") + " " + actionStyle.Render("Cancel")
+	if m.deleteLinked > 0 {
+		actions += "\n" + keyStyle.Render("[r]") + " " + actionStyle.Render(fmt.Sprintf("Also delete %d linked reminder(s)", m.deleteLinked))
+	}
 
-	modalContent := fmt.Sprintf("\n%s\n\nAre you sure you want to delete:\n\n  %s\n\n%s  %s\n",
+	modalContent := fmt.Sprintf("\n%s\n\n%s\n\n  %s\n\n%s\n",
 		modalTitle,
+		question,
 		lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("226")).Render(m.deleteTarget),
-		keyStyle.Render("[y]")+" "+actionStyle.Render("Confirm"),
-		keyStyle.Render("[n]")+" "+actionStyle.Render("Cancel"))
+		actions)
 
 	modal := modalStyle.Render(modalContent)
 
@@ -349,22 +556,106 @@ func (m model) homeView() string {
 		Padding(0, 1)
 
 	// Show summary stats
-	totalDailies := len(m.data.Dailies)
+	// Paused and archived dailies don't count toward today
+	active := activeDailies(m.data.Dailies)
+	totalDailies := len(active)
 	completedDailies := 0
-	for _, daily := range m.data.Dailies {
+	for _, daily := range active {
 		if daily.Status == "DONE" {
 			completedDailies++
 		}
 	}
 
 	var contentParts []string
+	if quests := m.questSummary(); quests != "" {
+		contentParts = append(contentParts, quests)
+	}
 
 	// Task Stats
 	progressContent := statusDoneStyle.Render("📊 Your Progress") + "\n"
 	progressContent += fmt.Sprintf("  Daily Tasks:         %d total, %d completed today\n", totalDailies, completedDailies)
 	progressContent += fmt.Sprintf("  Rolling Todos:       %d items\n", len(m.data.RollingTodos))
 	progressContent += fmt.Sprintf("  Active Reminders:    %d\n", len(m.data.Reminders))
+	if len(m.data.PomodoroLog) > 0 {
+		today, todayMinutes := pomodorosSince(m.data.PomodoroLog, dayStart(time.Now()))
+		progressContent += fmt.Sprintf("  Pomodoros:           %d today (%dm focus), %d total\n", today, todayMinutes, len(m.data.PomodoroLog))
+	}
+	if totalDailies > 0 {
+		progressContent += m.heatmapSummary()
+	}
+	xp := totalXP(m.data.XPLedger, "")
+	level, into, needed := levelProgress(xp)
+	progressContent += fmt.Sprintf("  Level:               ⭐ %d  %s %d/%d XP to level %d (%d total, +%d today)\n",
+		level, xpBar(into, needed, 20), into, needed, level+1, xp, totalXP(m.data.XPLedger, logicalDay(time.Now())))
+	progressContent += fmt.Sprintf("  Points:              💰 %d to spend ($ for the shop)\n", pointsBalance(m.data))
 	contentParts = append(contentParts, progressContent)
+	if recap := m.healthRecap(); recap != "" {
+		contentParts = append(contentParts, recap)
+	}
+
+	// Streak freezes: tokens left, and any used on yesterday's misses
+	yesterday := addDays(logicalDay(time.Now()), -1)
+	var frozen []string
+	for _, daily := range m.data.Dailies {
+		if daily.History[yesterday].Status == dayFrozen {
+			frozen = append(frozen, daily.Task)
+		}
+	}
+	if m.data.FreezeTokens > 0 || len(frozen) > 0 {
+		freezeContent := fmt.Sprintf("\n  ❄️  Streak freezes:     %d available", m.data.FreezeTokens)
+		if len(frozen) > 0 {
+			freezeContent += fmt.Sprintf(" (used yesterday: %s)", strings.Join(frozen, ", "))
+		}
+		contentParts = append(contentParts, freezeContent+"\n")
+	}
+	if m.data.Vacation.onVacation(logicalDay(time.Now())) {
+		contentParts = append(contentParts, fmt.Sprintf("  🏖️  On vacation until %s, dailies paused\n", m.data.Vacation.End))
+	}
+
+	// Routines with today's progress; the one whose window is open is flagged
+	if len(m.data.Routines) > 0 {
+		routineContent := "\n" + statusDoneStyle.Render("🧭 Routines") + "\n"
+		for _, r := range m.data.Routines {
+			done, total := m.routineCompletion(r)
+			line := fmt.Sprintf("  %-20s %d/%d done, streak %d", r.Name, done, total, r.CurrentStreak)
+			if r.Window != "" {
+				line += "  " + r.Window
+			}
+			if done < total && r.inWindow(time.Now()) {
+				line += " " + statusPendingStyle.Render("now, R to run")
+			}
+			routineContent += line + "\n"
+		}
+		contentParts = append(contentParts, routineContent)
+	}
+
+	// Streaks that break at the next day rollover unless completed
+	var atRisk []string
+	for _, daily := range active {
+		today := logicalDay(time.Now())
+		if daily.Status != "DONE" && daily.CurrentStreak > 0 && dueOn(daily, today) && !excusedOn(daily, today) && lastChanceOn(daily, today) {
+			unit := "days"
+			if period := dailySchedule(daily).period(); period != periodDay {
+				unit = period + "s"
+			}
+			line := fmt.Sprintf("  🔥 %s (%d %s)", daily.Task, daily.CurrentStreak, unit)
+			switch dailyNudgeStage(daily, time.Now()) {
+			case 2:
+				line += " " + statusOverdueStyle.Render("OVERDUE since "+daily.Deadline)
+			case 1:
+				line += " " + statusPendingStyle.Render("due by "+daily.Deadline)
+			}
+			atRisk = append(atRisk, line)
+		}
+	}
+	if len(atRisk) > 0 {
+		untilReset := time.Until(nextDayStart(time.Now()))
+		riskContent := "\n" + statusOverdueStyle.Render(fmt.Sprintf("⚠️  Streaks at Risk (reset in %s)", formatDuration(untilReset.Truncate(time.Minute)))) + "\n"
+		riskContent += strings.Join(atRisk, "\n") + "\n"
+		contentParts = append(contentParts, riskContent)
+	}
+
+	contentParts = append(contentParts, m.trophySummary())
 
 	// Rolling todos warning
 	if len(m.data.RollingTodos) > 0 {
@@ -373,78 +664,61 @@ func (m model) homeView() string {
 		contentParts = append(contentParts, todoWarning)
 	}
 
-	// Show expired reminders
-	expiredReminders := []Reminder{}
-	for _, reminder := range m.data.Reminders {
-		if reminder.Status == "expired" {
-			expiredReminders = append(expiredReminders, reminder)
+	// Reminders are selectable with j/k so linked items can be completed from here
+	expiredReminders, activeReminders := m.homeReminders()
+	cursorMark := func(index int) string {
+		if index == m.homeCursor {
+			return keyStyle.Render("▶ ")
 		}
+		return "  "
 	}
 
 	if len(expiredReminders) > 0 {
 		expiredContent := "\n" + statusOverdueStyle.Render("⚠️ Expired Reminders") + "\n"
-		for _, reminder := range expiredReminders {
-			expiredContent += fmt.Sprintf("  • %s\n", reminder.Reminder)
+		for i, reminder := range expiredReminders {
+			if isRinging(reminder) {
+				expiredContent += fmt.Sprintf("%s🔔 %s (ringing, x to acknowledge in Reminders)%s\n", cursorMark(i), reminder.Reminder, m.linkSuffix(reminder))
+			} else {
+				expiredContent += fmt.Sprintf("%s• %s%s\n", cursorMark(i), reminder.Reminder, m.linkSuffix(reminder))
+			}
 		}
 		contentParts = append(contentParts, expiredContent)
 	}
 
-	// Show active reminders with countdown
-	activeReminders := []Reminder{}
-	for _, reminder := range m.data.Reminders {
-		if !reminder.TargetTime.IsZero() && (reminder.Status == "active" || reminder.Status == "paused") {
-			activeReminders = append(activeReminders, reminder)
-		}
-	}
-
-	// Sort by time remaining (soonest first)
-	sort.Slice(activeReminders, func(i, j int) bool {
-		iRemaining := time.Until(activeReminders[i].TargetTime)
-		jRemaining := time.Until(activeReminders[j].TargetTime)
-
-		// Handle paused reminders - use PausedRemaining for comparison
-		if activeReminders[i].Status == "paused" && activeReminders[i].PausedRemaining > 0 {
-			iRemaining = activeReminders[i].PausedRemaining
-		}
-		if activeReminders[j].Status == "paused" && activeReminders[j].PausedRemaining > 0 {
-			jRemaining = activeReminders[j].PausedRemaining
-		}
-
-		// Sort by remaining time (ascending - soonest first)
-		return iRemaining < jRemaining
-	})
-
 	if len(activeReminders) > 0 {
 		reminderContent := "\n" + lipgloss.NewStyle().
 			Bold(true).
 			Foreground(lipgloss.Color("86")).
 			Render("🕐 Active Reminders") + "\n"
 
-		for _, reminder := range activeReminders {
+		for i, reminder := range activeReminders {
+			mark := cursorMark(len(expiredReminders) + i)
+			suffix := m.linkSuffix(reminder)
 			statusIcon := "🕐"
-			if reminder.Status == "paused" {
+			if reminder.Mode != "" {
+				reminderContent += fmt.Sprintf("%s%s: %s%s\n", mark, reminder.Reminder, timerDisplay(reminder, time.Now()), suffix)
+			} else if reminder.Status == "paused" {
 				statusIcon = "⏸️"
 				// Show paused remaining time
-				if reminder.PausedRemaining > 0 {
-					if reminder.IsCountdown {
-						reminderContent += fmt.Sprintf("  %s %s: %s (PAUSED)\n", statusIcon, reminder.Reminder, formatDuration(reminder.PausedRemaining))
-					} else {
-						reminderContent += fmt.Sprintf("  %s %s: PAUSED\n", statusIcon, reminder.Reminder)
-					}
+				if reminder.PausedRemaining > 0 && reminder.IsCountdown {
+					reminderContent += fmt.Sprintf("%s%s %s: %s (PAUSED)%s\n", mark, statusIcon, reminder.Reminder, formatDuration(reminder.PausedRemaining), suffix)
 				} else {
-					reminderContent += fmt.Sprintf("  %s %s: PAUSED\n", statusIcon, reminder.Reminder)
+					reminderContent += fmt.Sprintf("%s%s %s: PAUSED%s\n", mark, statusIcon, reminder.Reminder, suffix)
 				}
 			} else {
 				// Active reminder - show live countdown
 				remaining := time.Until(reminder.TargetTime)
+				if preAlerted(reminder.PreAlerts, reminder.TargetTime, time.Now()) {
+					statusIcon = "⏰"
+				}
 				if remaining > 0 {
 					if reminder.IsCountdown {
-						reminderContent += fmt.Sprintf("  %s %s: %s\n", statusIcon, reminder.Reminder, formatDuration(remaining))
+						reminderContent += fmt.Sprintf("%s%s %s: %s%s\n", mark, statusIcon, reminder.Reminder, formatDuration(remaining), suffix)
 					} else {
-						reminderContent += fmt.Sprintf("  %s %s: %s\n", statusIcon, reminder.Reminder, reminder.TargetTime.Format("15:04"))
+						reminderContent += fmt.Sprintf("%s%s %s: %s%s\n", mark, statusIcon, reminder.Reminder, reminder.TargetTime.Format("15:04"), suffix)
 					}
 				} else {
-					reminderContent += fmt.Sprintf("  ⚠️ %s: EXPIRED\n", reminder.Reminder)
+					reminderContent += fmt.Sprintf("%s⚠️ %s: EXPIRED%s\n", mark, reminder.Reminder, suffix)
 				}
 			}
 		}
@@ -456,6 +730,15 @@ func (m model) homeView() string {
 	return contentStyle.Render(content)
 }
 
+// linkSuffix names the daily or todo a reminder is attached to
+func (m model) linkSuffix(reminder Reminder) string {
+	name, ok := linkedName(m.data, reminder.LinkType, reminder.LinkID)
+	if !ok {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(fmt.Sprintf(" → %s (c: complete)", name))
+}
+
 func (m model) referenceView() string {
 	// Reference tab with search
 	var searchBox string
@@ -523,6 +806,11 @@ func (m model) helpView() string {
 	allHelpContent = append(allHelpContent, sectionStyle.Render("Global:"))
 	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s         Switch between tabs", keyStyle.Render("1-5")))
 	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s         Navigate tabs", keyStyle.Render("←/→")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Notification history", keyStyle.Render("i")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Message scrollback", keyStyle.Render("m")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Rewards shop: spend points, u undoes a purchase", keyStyle.Render("$")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s         Dismiss newest message", keyStyle.Render("esc")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Toggle do-not-disturb", keyStyle.Render("z")))
 	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Toggle this help screen", keyStyle.Render("?")))
 	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s   Quit application", keyStyle.Render("q / ctrl+c")))
 	allHelpContent = append(allHelpContent, "")
@@ -530,15 +818,30 @@ func (m model) helpView() string {
 	// Home section
 	allHelpContent = append(allHelpContent, sectionStyle.Render("Home (Tab 1):"))
 	allHelpContent = append(allHelpContent, "  View your stats and active reminders")
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s    Select reminder", keyStyle.Render("↑/↓ / j/k")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Complete the reminder's linked task", keyStyle.Render("c")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Run the routine whose window is open", keyStyle.Render("R")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Trophies: every achievement and your progress", keyStyle.Render("T")))
 	allHelpContent = append(allHelpContent, "")
 
 	// Daily Tasks section
 	allHelpContent = append(allHelpContent, sectionStyle.Render("Daily Tasks (Tab 2):"))
 	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s    Navigate list", keyStyle.Render("↑/↓ / j/k")))
-	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s  Toggle task completion (builds streaks!)", keyStyle.Render("space / enter")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Toggle task completion (builds streaks!), or a slip on 🚫 avoid habits", keyStyle.Render("space")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Heatmap of the last 52 weeks ([ ] to switch habits)", keyStyle.Render("enter")))
 	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Edit selected task", keyStyle.Render("e")))
 	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s         Add new task", keyStyle.Render("n / a")))
 	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Delete task", keyStyle.Render("d")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Add linked reminder", keyStyle.Render("b")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Skip today (keeps streak)", keyStyle.Render("x")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s         Log progress on a target", keyStyle.Render("+ / -")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Mark or unmark past days", keyStyle.Render("B")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Pause / resume (streak held)", keyStyle.Render("p")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Archive / restore (history kept)", keyStyle.Render("v")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Show archived dailies", keyStyle.Render("V")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Group by routine", keyStyle.Render("g")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Collapse / expand the selected routine", keyStyle.Render("o")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Run the selected daily's routine step by step", keyStyle.Render("R")))
 	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Cycle sort (Task/Priority/Category/Streak)", keyStyle.Render("s")))
 	allHelpContent = append(allHelpContent, "")
 
@@ -547,7 +850,9 @@ func (m model) helpView() string {
 	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s    Navigate list", keyStyle.Render("↑/↓ / j/k")))
 	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Edit selected todo", keyStyle.Render("e")))
 	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s         Add new todo", keyStyle.Render("n / a")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s  Complete todo", keyStyle.Render("space / enter")))
 	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Delete todo", keyStyle.Render("d")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Add linked reminder (e.g. 2d before deadline)", keyStyle.Render("b")))
 	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Cycle sort", keyStyle.Render("s")))
 	allHelpContent = append(allHelpContent, "")
 
@@ -560,6 +865,9 @@ func (m model) helpView() string {
 	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Start/resume reminder", keyStyle.Render("s")))
 	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Pause reminder", keyStyle.Render("p")))
 	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Reset reminder", keyStyle.Render("r")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Acknowledge (stops repeats)", keyStyle.Render("x")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Record stopwatch lap", keyStyle.Render("l")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Complete linked task", keyStyle.Render("c")))
 	allHelpContent = append(allHelpContent, "")
 
 	// Reference section
@@ -572,6 +880,15 @@ func (m model) helpView() string {
 	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Delete command", keyStyle.Render("d")))
 	allHelpContent = append(allHelpContent, "")
 
+	// Notifications section
+	allHelpContent = append(allHelpContent, sectionStyle.Render("Notifications (i):"))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s       Jump to the source item", keyStyle.Render("enter")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Acknowledge", keyStyle.Render("a")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Re-send notification", keyStyle.Render("r")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Snooze for 10 minutes", keyStyle.Render("s")))
+	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s           Complete the linked task", keyStyle.Render("c")))
+	allHelpContent = append(allHelpContent, "")
+
 	// Edit Mode section
 	allHelpContent = append(allHelpContent, sectionStyle.Render("Edit Mode:"))
 	allHelpContent = append(allHelpContent, fmt.Sprintf("  %s         Next field", keyStyle.Render("tab")))
@@ -635,23 +952,31 @@ func (m model) editView() string {
 
 	switch m.editingTab {
 	case 2: // Dailies
-		labels = []string{"Task:", "Priority:", "Category:", "Deadline:", "Status:"}
+		labels = []string{"Task:", "Priority:", "Category:", "Due by (e.g. 21:00, 9pm):", "Schedule (blank = every day, mon/wed/fri, every 2 days, 3x/week, weekly, monthly):", "Target (e.g. 8 glasses, 10k steps +1000, blank = yes/no):", "Avoid habit, counts days clean (y/n):"}
 	case 3: // Rolling Todos
-		labels = []string{"Task:", "Priority:", "Category:", "Deadline:"}
+		labels = []string{"Task:", "Priority:", "Category:", "Deadline (YYYY-MM-DD [HH:MM]):", "Pre-alerts (e.g. 1h, 1d):"}
 	case 4: // Reminders
-		labels = []string{"Reminder:", "Note:", "Alarm/Countdown:"}
+		labels = []string{"Reminder:", "Note:", "Alarm/Countdown (or stopwatch, pomodoro 25/5/15/4):", "Repeat every (e.g. 5m, blank = once):", "Escalate repeats (y/n):", "Sound file (blank = default):", "Volume (1-100, blank = default):", "Pre-alerts (e.g. 10m, 1h, 1d):", "Urgent, bypasses quiet hours (y/n):"}
 	case 5: // Reference
 		labels = []string{"Lang:", "Command:", "Usage:", "Example:", "Meaning:"}
 	}
 
+	// Long forms put each label beside its input so they fit small terminals
+	separator := "\n"
+	if len(m.inputs) > 5 {
+		separator = " "
+	}
 	for i, input := range m.inputs {
 		label := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("86")).Render(labels[i])
-		fields = append(fields, label+"\n"+input.View())
+		fields = append(fields, label+separator+input.View())
 	}
 
 	content := lipgloss.JoinVertical(lipgloss.Top, fields...)
 
 	header := headerStyle.Render("✏️ Editing Mode")
+	if name, ok := linkedName(m.data, m.linkType, m.linkID); ok && m.editingTab == 4 {
+		header += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(fmt.Sprintf("🔗 Reminder for %s: %s (e.g. 2d before deadline)", m.linkType, name))
+	}
 	footer := keyStyle.Render("tab") + colonStyle.Render(": ") + actionStyle.Render("next field") + colonStyle.Render(" ") + bulletStyle.Render("•") + colonStyle.Render(" ") + keyStyle.Render("shift+tab") + colonStyle.Render(": ") + actionStyle.Render("prev field") + colonStyle.Render(" ") + bulletStyle.Render("•") + colonStyle.Render(" ") + keyStyle.Render("enter") + colonStyle.Render(": ") + actionStyle.Render("save") + colonStyle.Render(" ") + bulletStyle.Render("•") + colonStyle.Render(" ") + keyStyle.Render("esc") + colonStyle.Render(": ") + actionStyle.Render("cancel")
 
 	return lipgloss.JoinVertical(lipgloss.Top,